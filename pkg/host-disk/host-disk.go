@@ -20,10 +20,21 @@
 package hostdisk
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 
 	"kubevirt.io/client-go/log"
@@ -36,20 +47,43 @@ import (
 	"kubevirt.io/kubevirt/pkg/util/types"
 )
 
-var pvcBaseDir = "/var/run/kubevirt-private/vmi-disks"
+// DefaultPVCBaseDir is the directory in which the disk directories of the volumes are mounted into the pod
+const DefaultPVCBaseDir = "/var/run/kubevirt-private/vmi-disks"
 
 const (
 	EventReasonToleratedSmallPV = "ToleratedSmallPV"
 	EventTypeToleratedSmallPV   = k8sv1.EventTypeNormal
+
+	EventReasonPreallocationUnsupported = "PreallocationUnsupported"
+	EventTypePreallocationUnsupported   = k8sv1.EventTypeWarning
+
+	EventReasonHostDiskResized    = "HostDiskResized"
+	EventTypeHostDiskResized      = k8sv1.EventTypeNormal
+	EventReasonHostDiskNotResized = "HostDiskNotResized"
+	EventTypeHostDiskNotResized   = k8sv1.EventTypeWarning
 )
 
-// Used by tests.
-func setDiskDirectory(dir string) error {
-	pvcBaseDir = dir
-	return os.MkdirAll(dir, 0750)
-}
+// DefaultCreateConcurrency is the number of disk images created in parallel by default
+const DefaultCreateConcurrency = 4
+
+// createdMarkerSuffix is appended to the disk image path to mark images created by KubeVirt, only those are grown
+const createdMarkerSuffix = ".kubevirt-created"
 
+// checksumFileSuffix is appended to the disk image path to get the file with its recorded checksum
+const checksumFileSuffix = ".sha256"
+
+// blankChecksumPrefix marks a recorded checksum of a blank raw image which only holds the image size so far
+const blankChecksumPrefix = "blank:"
+
+// qcow2ClusterSize is the default cluster size used by qemu-img for qcow2 images
+const qcow2ClusterSize = 64 << 10
+
+// ReplacePVCByHostDisk replaces the filesystem PVCs of the VMI by HostDisks within the default disk directory
 func ReplacePVCByHostDisk(vmi *v1.VirtualMachineInstance) error {
+	return replacePVCByHostDisk(DefaultPVCBaseDir, vmi)
+}
+
+func replacePVCByHostDisk(pvcBaseDir string, vmi *v1.VirtualMachineInstance) error {
 	// If PVC is defined and it's not a BlockMode PVC, then it is replaced by HostDisk
 	// Filesystem PersistenVolumeClaim is mounted into pod as directory from node filesystem
 	passthoughFSVolumes := make(map[string]struct{})
@@ -94,7 +128,7 @@ func ReplacePVCByHostDisk(vmi *v1.VirtualMachineInstance) error {
 			}
 
 			isShared := types.HasSharedAccessMode(volumeStatus.PersistentVolumeClaimInfo.AccessModes)
-			file := getPVCDiskImgPath(vmi.Spec.Volumes[i].Name, "disk.img")
+			file := pvcDiskImgPath(pvcBaseDir, vmi.Spec.Volumes[i].Name, "disk.img")
 			volumeSource.HostDisk = &v1.HostDisk{
 				Path:     file,
 				Type:     v1.HostDiskExistsOrCreate,
@@ -113,13 +147,25 @@ func ReplacePVCByHostDisk(vmi *v1.VirtualMachineInstance) error {
 	return nil
 }
 
+func filesystemID(path string) (uint64, error) {
+	var stat syscall.Stat_t
+	if err := syscall.Stat(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Dev), nil
+}
+
 func dirBytesAvailable(path string, reserve uint64) (uint64, error) {
 	var stat syscall.Statfs_t
 	err := syscall.Statfs(path, &stat)
 	if err != nil {
 		return 0, err
 	}
-	return stat.Bavail*uint64(stat.Bsize) - reserve, nil
+	available := stat.Bavail * uint64(stat.Bsize)
+	if available < reserve {
+		return 0, nil
+	}
+	return available - reserve, nil
 }
 
 func createSparseRaw(fullPath string, size int64) (err error) {
@@ -136,68 +182,458 @@ func createSparseRaw(fullPath string, size int64) (err error) {
 	return nil
 }
 
-func getPVCDiskImgPath(volumeName string, diskName string) string {
+func createPreallocatedRaw(fullPath string, size int64) error {
+	return preallocateRaw(fullPath, size, syscall.Fallocate)
+}
+
+// preallocateRaw removes the file again if its blocks could not be allocated,
+// otherwise it would be taken for an existing image on the next start
+func preallocateRaw(fullPath string, size int64, fallocate func(fd int, mode uint32, off int64, len int64) error) (err error) {
+	f, err := os.Create(fullPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		util.CloseIOAndCheckErr(f, &err)
+		if err != nil {
+			if removeErr := os.Remove(fullPath); removeErr != nil && !os.IsNotExist(removeErr) {
+				log.Log.Reason(removeErr).Errorf("Couldn't remove the partially created disk image %s: %v", fullPath, removeErr)
+			}
+		}
+	}()
+	return fallocate(int(f.Fd()), 0, 0, size)
+}
+
+// growPreallocatedRaw grows the raw image to size, allocating the blocks of the new range
+func growPreallocatedRaw(fullPath string, currentSize int64, size int64) (err error) {
+	f, err := os.OpenFile(fullPath, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer util.CloseIOAndCheckErr(f, &err)
+	return syscall.Fallocate(int(f.Fd()), 0, currentSize, size-currentSize)
+}
+
+func createQcow2(fullPath string, size int64) ([]byte, error) {
+	// #nosec No risk for attacket injection. Parameters are predefined strings
+	cmd := exec.Command("qemu-img",
+		"create",
+		"-f",
+		"qcow2",
+		fullPath,
+		strconv.FormatInt(size, 10),
+	)
+	return cmd.CombinedOutput()
+}
+
+func resizeQcow2(fullPath string, size int64) ([]byte, error) {
+	// #nosec No risk for attacket injection. Parameters are predefined strings
+	cmd := exec.Command("qemu-img",
+		"resize",
+		"-f",
+		"qcow2",
+		fullPath,
+		strconv.FormatInt(size, 10),
+	)
+	return cmd.CombinedOutput()
+}
+
+// qcow2VirtualSize reads the virtual size of a qcow2 image from its header
+func qcow2VirtualSize(fullPath string) (size int64, err error) {
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return 0, err
+	}
+	defer util.CloseIOAndCheckErr(f, &err)
+	header := make([]byte, 32)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return 0, fmt.Errorf("failed to read the qcow2 header of %s: %v", fullPath, err)
+	}
+	if !bytes.Equal(header[:4], []byte{'Q', 'F', 'I', 0xfb}) {
+		return 0, fmt.Errorf("%s is not a qcow2 image", fullPath)
+	}
+	return int64(binary.BigEndian.Uint64(header[24:32])), nil
+}
+
+func imageSize(fullPath string, format v1.HostDiskFormat) (int64, error) {
+	if format == v1.HostDiskFormatQcow2 {
+		return qcow2VirtualSize(fullPath)
+	}
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// allocatedBytes returns the space taken by the image on the filesystem, which is less than its size if it is sparse
+func allocatedBytes(fullPath string) (int64, error) {
+	var stat syscall.Stat_t
+	if err := syscall.Stat(fullPath, &stat); err != nil {
+		return 0, err
+	}
+	// st_blocks is always counted in 512 byte units
+	return stat.Blocks * 512, nil
+}
+
+// qcow2MetadataOverhead estimates the space taken by the qcow2 header, L1/L2 tables and
+// refcount blocks once an image of the given virtual size is fully allocated.
+func qcow2MetadataOverhead(size int64) int64 {
+	clusters := (size + qcow2ClusterSize - 1) / qcow2ClusterSize
+	// every cluster needs an 8 byte L2 entry and a 2 byte refcount entry
+	tables := (clusters*8+qcow2ClusterSize-1)/qcow2ClusterSize + (clusters*2+qcow2ClusterSize-1)/qcow2ClusterSize
+	// header, L1 table and refcount table
+	return (tables + 3) * qcow2ClusterSize
+}
+
+// resolvePath follows the symlinks of the existing part of the path
+func resolvePath(p string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(p)
+	if err == nil {
+		return resolved, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	// A dangling symlink would still be followed when the file is created
+	if target, err := os.Readlink(p); err == nil {
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(p), target)
+		}
+		return resolvePath(target)
+	}
+	parent := filepath.Dir(p)
+	if parent == p {
+		return p, nil
+	}
+	resolvedParent, err := resolvePath(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolvedParent, filepath.Base(p)), nil
+}
+
+// validateDiskImgPath makes sure that the disk image path, with its symlinks resolved, stays within pvcBaseDir
+func validateDiskImgPath(pvcBaseDir string, diskPath string) error {
+	baseDir, err := resolvePath(pvcBaseDir)
+	if err != nil {
+		return err
+	}
+	resolved, err := resolvePath(diskPath)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(baseDir, resolved)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return fmt.Errorf("disk image path %s resolves to %s, which is not within %s", diskPath, resolved, pvcBaseDir)
+	}
+	return nil
+}
+
+func fileChecksum(fullPath string) (checksum string, err error) {
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return "", err
+	}
+	defer util.CloseIOAndCheckErr(f, &err)
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// zeroReader returns an endless stream of zeros
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// blankRawChecksum returns the checksum of a blank raw image of the given size without reading it.
+// It still hashes size bytes, so it is only used when a checksum is verified.
+func blankRawChecksum(size int64) (string, error) {
+	hash := sha256.New()
+	if _, err := io.CopyN(hash, zeroReader{}, size); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeChecksum records the checksum of the disk image next to it, in the format used by sha256sum.
+// The file is renamed into place, so it never holds a partial checksum.
+func writeChecksum(diskPath string, checksum string) error {
+	content := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(diskPath))
+	tmpPath := diskPath + checksumFileSuffix + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, []byte(content), 0640); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, diskPath+checksumFileSuffix)
+}
+
+func verifyHostDiskChecksum(diskDir string, volumeName string) (bool, error) {
+	checksumFiles, err := filepath.Glob(path.Join(diskDir, "*"+checksumFileSuffix))
+	if err != nil {
+		return false, err
+	}
+	if len(checksumFiles) == 0 {
+		return false, fmt.Errorf("no checksum recorded for volume %s", volumeName)
+	}
+	for _, checksumFile := range checksumFiles {
+		content, err := ioutil.ReadFile(checksumFile)
+		if err != nil {
+			return false, err
+		}
+		fields := strings.Fields(string(content))
+		if len(fields) != 2 || filepath.Base(fields[1]) != fields[1] {
+			return false, fmt.Errorf("invalid checksum file %s", checksumFile)
+		}
+		diskPath := path.Join(diskDir, fields[1])
+		expected := fields[0]
+		if strings.HasPrefix(expected, blankChecksumPrefix) {
+			if expected, err = resolveBlankChecksum(diskPath, strings.TrimPrefix(expected, blankChecksumPrefix)); err != nil {
+				return false, fmt.Errorf("invalid checksum file %s: %v", checksumFile, err)
+			}
+		}
+		checksum, err := fileChecksum(diskPath)
+		if err != nil {
+			return false, err
+		}
+		if checksum != expected {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// resolveBlankChecksum computes the checksum of a blank raw image from its recorded size
+// and records it in place of the size, so it is only computed once
+func resolveBlankChecksum(diskPath string, recordedSize string) (string, error) {
+	size, err := strconv.ParseInt(recordedSize, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	checksum, err := blankRawChecksum(size)
+	if err != nil {
+		return "", err
+	}
+	if err := writeChecksum(diskPath, checksum); err != nil {
+		log.Log.Reason(err).Warningf("Couldn't record the checksum of %s: %v", diskPath, err)
+	}
+	return checksum, nil
+}
+
+func pvcDiskImgPath(pvcBaseDir string, volumeName string, diskName string) string {
 	return path.Join(pvcBaseDir, volumeName, diskName)
 }
 
 func GetMountedHostDiskPath(volumeName string, path string) string {
-	return getPVCDiskImgPath(volumeName, filepath.Base(path))
+	return pvcDiskImgPath(DefaultPVCBaseDir, volumeName, filepath.Base(path))
 }
 
 func GetMountedHostDiskDir(volumeName string) string {
-	return getPVCDiskImgPath(volumeName, "")
+	return pvcDiskImgPath(DefaultPVCBaseDir, volumeName, "")
 }
 
 type DiskImgCreator struct {
 	dirBytesAvailableFunc  func(path string, reserve uint64) (uint64, error)
+	createQcow2Func        func(fullPath string, size int64) ([]byte, error)
+	resizeQcow2Func        func(fullPath string, size int64) ([]byte, error)
+	preallocateFunc        func(fullPath string, size int64) error
+	growPreallocatedFunc   func(fullPath string, currentSize int64, size int64) error
+	filesystemIDFunc       func(path string) (uint64, error)
 	notifier               k8sNotifier
 	lessPVCSpaceToleration int
 	minimumPVCReserveBytes uint64
+	preallocation          bool
+	concurrency            int
+	recordChecksums        bool
+	onSizeAdjusted         func(volumeName string, requested, available int64)
+	pvcBaseDir             string
+}
+
+// spaceReservations tracks the space promised to the images created by a single Create call per filesystem.
+// Sparse images don't consume it right away, so the filesystem alone would let them over-commit.
+type spaceReservations struct {
+	lock     sync.Mutex
+	reserved map[uint64]int64
+}
+
+func (r *spaceReservations) release(fsID uint64, size int64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reserved[fsID] -= size
 }
 
 type k8sNotifier interface {
 	SendK8sEvent(vmi *v1.VirtualMachineInstance, severity string, reason string, message string) error
 }
 
-func NewHostDiskCreator(notifier k8sNotifier, lessPVCSpaceToleration int, minimumPVCReserveBytes uint64) DiskImgCreator {
+// HostDiskCreatorOptions configures a DiskImgCreator, the zero value creates sparse images in the default disk directory
+type HostDiskCreatorOptions struct {
+	// LessPVCSpaceToleration is the percentage by which an image may be created smaller than requested
+	LessPVCSpaceToleration int
+	// MinimumPVCReserveBytes is kept free on the PV when an image does not fit
+	MinimumPVCReserveBytes uint64
+	// Preallocation allocates the blocks of raw images instead of creating them sparse
+	Preallocation bool
+	// Concurrency is the number of images created in parallel, DefaultCreateConcurrency if not set
+	Concurrency int
+	// RecordChecksums records the checksum of created images next to them
+	RecordChecksums bool
+	// PVCBaseDir is the directory containing the disk directories of the volumes, DefaultPVCBaseDir if not set
+	PVCBaseDir string
+}
+
+func NewHostDiskCreator(notifier k8sNotifier, options HostDiskCreatorOptions) DiskImgCreator {
+	concurrency := options.Concurrency
+	if concurrency == 0 {
+		concurrency = DefaultCreateConcurrency
+	}
+	baseDir := options.PVCBaseDir
+	if baseDir == "" {
+		baseDir = DefaultPVCBaseDir
+	}
 	return DiskImgCreator{
 		dirBytesAvailableFunc:  dirBytesAvailable,
+		createQcow2Func:        createQcow2,
+		resizeQcow2Func:        resizeQcow2,
+		preallocateFunc:        createPreallocatedRaw,
+		growPreallocatedFunc:   growPreallocatedRaw,
+		filesystemIDFunc:       filesystemID,
 		notifier:               notifier,
-		lessPVCSpaceToleration: lessPVCSpaceToleration,
-		minimumPVCReserveBytes: minimumPVCReserveBytes,
+		lessPVCSpaceToleration: options.LessPVCSpaceToleration,
+		minimumPVCReserveBytes: options.MinimumPVCReserveBytes,
+		preallocation:          options.Preallocation,
+		concurrency:            concurrency,
+		recordChecksums:        options.RecordChecksums,
+		pvcBaseDir:             baseDir,
 	}
 }
 
+func (hdc DiskImgCreator) getPVCDiskImgPath(volumeName string, diskName string) string {
+	return pvcDiskImgPath(hdc.pvcBaseDir, volumeName, diskName)
+}
+
+// GetMountedHostDiskPath returns the path of the disk image of the volume within the base directory of the creator
+func (hdc DiskImgCreator) GetMountedHostDiskPath(volumeName string, path string) string {
+	return hdc.getPVCDiskImgPath(volumeName, filepath.Base(path))
+}
+
+// GetMountedHostDiskDir returns the disk directory of the volume within the base directory of the creator
+func (hdc DiskImgCreator) GetMountedHostDiskDir(volumeName string) string {
+	return hdc.getPVCDiskImgPath(volumeName, "")
+}
+
+// ReplacePVCByHostDisk replaces the filesystem PVCs of the VMI by HostDisks within the base directory of the creator
+func (hdc DiskImgCreator) ReplacePVCByHostDisk(vmi *v1.VirtualMachineInstance) error {
+	return replacePVCByHostDisk(hdc.pvcBaseDir, vmi)
+}
+
+// VerifyHostDiskChecksum compares the disk images of the volume with the checksums recorded when they were created
+func (hdc DiskImgCreator) VerifyHostDiskChecksum(volumeName string) (bool, error) {
+	return verifyHostDiskChecksum(hdc.GetMountedHostDiskDir(volumeName), volumeName)
+}
+
 func (hdc *DiskImgCreator) setlessPVCSpaceToleration(toleration int) {
 	hdc.lessPVCSpaceToleration = toleration
 }
 
+// SetOnSizeAdjusted registers a callback invoked with the requested and the actual size in bytes
+// whenever a disk image had to be created smaller than requested. It may be called concurrently.
+func (hdc *DiskImgCreator) SetOnSizeAdjusted(onSizeAdjusted func(volumeName string, requested, available int64)) {
+	hdc.onSizeAdjusted = onSizeAdjusted
+}
+
+// Create creates the missing disk images of the HostDisk volumes of the VMI, up to concurrency images at a time.
+// The first failure is returned and the images which were not started yet are skipped.
 func (hdc DiskImgCreator) Create(vmi *v1.VirtualMachineInstance) error {
+	var volumes []v1.Volume
 	for _, volume := range vmi.Spec.Volumes {
-		if hostDisk := volume.VolumeSource.HostDisk; shouldMountHostDisk(hostDisk) {
-			if err := hdc.mountHostDiskAndSetOwnership(vmi, volume.Name, hostDisk); err != nil {
-				return err
+		if shouldMountHostDisk(volume.VolumeSource.HostDisk) {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reservations := &spaceReservations{reserved: make(map[uint64]int64)}
+	pending := make(chan v1.Volume)
+	errs := make(chan error, len(volumes))
+	workers := hdc.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for volume := range pending {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := hdc.mountHostDiskAndSetOwnership(vmi, volume.Name, volume.VolumeSource.HostDisk, reservations); err != nil {
+					errs <- err
+					cancel()
+				}
 			}
+		}()
+	}
+
+feed:
+	for _, volume := range volumes {
+		select {
+		case pending <- volume:
+		case <-ctx.Done():
+			break feed
 		}
 	}
-	return nil
+	close(pending)
+	wg.Wait()
+	close(errs)
+
+	// nil once all images were created
+	return <-errs
 }
 
 func shouldMountHostDisk(hostDisk *v1.HostDisk) bool {
 	return hostDisk != nil && hostDisk.Type == v1.HostDiskExistsOrCreate && hostDisk.Path != ""
 }
 
-func (hdc *DiskImgCreator) mountHostDiskAndSetOwnership(vmi *v1.VirtualMachineInstance, volumeName string, hostDisk *v1.HostDisk) error {
-	diskPath := GetMountedHostDiskPath(volumeName, hostDisk.Path)
-	diskDir := GetMountedHostDiskDir(volumeName)
+func (hdc *DiskImgCreator) mountHostDiskAndSetOwnership(vmi *v1.VirtualMachineInstance, volumeName string, hostDisk *v1.HostDisk, reservations *spaceReservations) error {
+	diskPath := hdc.GetMountedHostDiskPath(volumeName, hostDisk.Path)
+	diskDir := hdc.GetMountedHostDiskDir(volumeName)
+	if err := validateDiskImgPath(hdc.pvcBaseDir, diskPath); err != nil {
+		return err
+	}
 	fileExists, err := ephemeraldiskutils.FileExists(diskPath)
 	if err != nil {
 		return err
 	}
 	if !fileExists {
-		if err := hdc.handleRequestedSizeAndCreateSparseRaw(vmi, diskDir, diskPath, hostDisk); err != nil {
+		size, err := hdc.handleRequestedSizeAndCreateSparseRaw(vmi, diskDir, diskPath, hostDisk, reservations)
+		if err != nil {
 			return err
 		}
+		// Without the marker the image is never grown, which is the safe side
+		if err := ioutil.WriteFile(diskPath+createdMarkerSuffix, nil, 0640); err != nil {
+			log.Log.Reason(err).Warningf("Couldn't mark %s as created by KubeVirt: %v", diskPath, err)
+		}
+		if requestedSize, _ := hostDisk.Capacity.AsInt64(); size < requestedSize && hdc.onSizeAdjusted != nil {
+			hdc.onSizeAdjusted(volumeName, requestedSize, size)
+		}
+		// Only blank images which are not shared with other nodes get a checksum
+		if hdc.recordChecksums && (hostDisk.Shared == nil || !*hostDisk.Shared) {
+			hdc.recordChecksum(diskPath, size, hostDisk)
+		}
+	} else if err := hdc.growImage(vmi, diskDir, diskPath, hostDisk, reservations); err != nil {
+		return err
 	}
 	// Change file ownership to the qemu user.
 	if err := ephemeraldiskutils.DefaultOwnershipManager.SetFileOwnership(diskPath); err != nil {
@@ -207,27 +643,225 @@ func (hdc *DiskImgCreator) mountHostDiskAndSetOwnership(vmi *v1.VirtualMachineIn
 	return nil
 }
 
-func (hdc *DiskImgCreator) handleRequestedSizeAndCreateSparseRaw(vmi *v1.VirtualMachineInstance, diskDir string, diskPath string, hostDisk *v1.HostDisk) error {
-	size, err := hdc.dirBytesAvailableFunc(diskDir, hdc.minimumPVCReserveBytes)
-	availableSize := int64(size)
+// recordChecksum records the checksum of a freshly created image. A blank raw image only holds zeros,
+// so only its size is recorded and its checksum is computed on the first verification, not on the start path.
+// A blank qcow2 image only holds its metadata and is small enough to be read right away.
+func (hdc *DiskImgCreator) recordChecksum(diskPath string, size int64, hostDisk *v1.HostDisk) {
+	checksum := blankChecksumPrefix + strconv.FormatInt(size, 10)
+	var err error
+	if hostDisk.Format == v1.HostDiskFormatQcow2 {
+		checksum, err = fileChecksum(diskPath)
+	}
+	if err == nil {
+		err = writeChecksum(diskPath, checksum)
+	}
 	if err != nil {
-		return err
+		log.Log.Reason(err).Warningf("Couldn't record the checksum of %s: %v", diskPath, err)
+	}
+}
+
+// removeChecksum drops the recorded checksum of a resized image, it no longer describes the image
+func (hdc *DiskImgCreator) removeChecksum(diskPath string) {
+	if err := os.Remove(diskPath + checksumFileSuffix); err != nil && !os.IsNotExist(err) {
+		log.Log.Reason(err).Warningf("Couldn't remove the checksum of %s: %v", diskPath, err)
 	}
+}
+
+// handleRequestedSizeAndCreateSparseRaw creates the disk image and returns its size
+func (hdc *DiskImgCreator) handleRequestedSizeAndCreateSparseRaw(vmi *v1.VirtualMachineInstance, diskDir string, diskPath string, hostDisk *v1.HostDisk, reservations *spaceReservations) (int64, error) {
+	fsID, err := hdc.filesystemIDFunc(diskDir)
+	if err != nil {
+		return 0, err
+	}
+	requestedSize, err := hdc.reserveRequestedSize(vmi, diskDir, fsID, hostDisk, reservations)
+	if err != nil {
+		return 0, err
+	}
+	allocated, err := hdc.createImage(vmi, diskPath, requestedSize, hostDisk)
+	if err != nil || allocated {
+		// A failed image needs no space and a preallocated one is already accounted for by the filesystem
+		reservations.release(fsID, requestedSize)
+	}
+	return requestedSize, err
+}
+
+func (hdc *DiskImgCreator) reserveRequestedSize(vmi *v1.VirtualMachineInstance, diskDir string, fsID uint64, hostDisk *v1.HostDisk, reservations *spaceReservations) (int64, error) {
+	reservations.lock.Lock()
+	defer reservations.lock.Unlock()
+
 	requestedSize, _ := hostDisk.Capacity.AsInt64()
+	availableSize, err := hdc.availableSize(diskDir, fsID, requestedSize, hostDisk, reservations)
+	if err != nil {
+		return 0, err
+	}
 	if requestedSize > availableSize {
 		requestedSize, err = hdc.shrinkRequestedSize(vmi, requestedSize, availableSize, hostDisk)
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
-	err = createSparseRaw(diskPath, requestedSize)
+	reservations.reserved[fsID] += requestedSize
+	return requestedSize, nil
+}
+
+// availableSize returns the space left for an image of the given size, reservations must be locked
+func (hdc *DiskImgCreator) availableSize(diskDir string, fsID uint64, size int64, hostDisk *v1.HostDisk, reservations *spaceReservations) (int64, error) {
+	reserve := hdc.minimumPVCReserveBytes
+	if hostDisk.Format == v1.HostDiskFormatQcow2 {
+		// The qcow2 metadata grows with the guest writes, keep room for it next to the image
+		reserve += uint64(qcow2MetadataOverhead(size))
+	}
+	available, err := hdc.dirBytesAvailableFunc(diskDir, reserve)
 	if err != nil {
-		log.Log.Reason(err).Errorf("Couldn't create a sparse raw file for disk path: %s, error: %v", diskPath, err)
+		return 0, err
+	}
+	return int64(available) - reservations.reserved[fsID], nil
+}
+
+// growImage grows an existing disk image created by KubeVirt which is smaller than requested, it never shrinks
+// images and leaves the ones provided by the user alone.
+// The space already allocated by the image counts as available, so an image which was created smaller
+// within the toleration is not grown beyond what the PV can hold. If there is not even room for the
+// tolerated size, the image is left as it is.
+func (hdc *DiskImgCreator) growImage(vmi *v1.VirtualMachineInstance, diskDir string, diskPath string, hostDisk *v1.HostDisk, reservations *spaceReservations) error {
+	requestedSize, _ := hostDisk.Capacity.AsInt64()
+	currentSize, err := imageSize(diskPath, hostDisk.Format)
+	if err != nil {
+		return err
+	}
+	if requestedSize <= currentSize {
+		return nil
+	}
+	markerExists, err := ephemeraldiskutils.FileExists(diskPath + createdMarkerSuffix)
+	if err != nil {
+		return err
+	}
+	if !markerExists {
+		log.Log.V(4).Infof("the disk image %s was not created by KubeVirt, will not resize it", diskPath)
+		return nil
+	}
+	allocated, err := allocatedBytes(diskPath)
+	if err != nil {
+		return err
+	}
+	fsID, err := hdc.filesystemIDFunc(diskDir)
+	if err != nil {
+		return err
+	}
+
+	toleratedSize := requestedSize * (100 - int64(hdc.lessPVCSpaceToleration)) / 100
+	reservations.lock.Lock()
+	availableSize, err := hdc.availableSize(diskDir, fsID, requestedSize, hostDisk, reservations)
+	targetSize := requestedSize
+	if err == nil {
+		if capacity := availableSize + allocated; targetSize > capacity {
+			targetSize = capacity
+		}
+		if targetSize > currentSize && targetSize >= toleratedSize {
+			reservations.reserved[fsID] += targetSize - allocated
+		}
+	}
+	reservations.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	if targetSize < toleratedSize {
+		if currentSize < toleratedSize {
+			msg := fmt.Sprintf("Not resizing disk image %s from %d B to %d B, only %d B are available", diskPath, currentSize, requestedSize, targetSize)
+			log.Log.Info(msg)
+			if err := hdc.notifier.SendK8sEvent(vmi, EventTypeHostDiskNotResized, EventReasonHostDiskNotResized, msg); err != nil {
+				log.Log.Reason(err).Warningf("Couldn't send k8s event for not resized disk image: %v", err)
+			}
+		}
+		return nil
+	}
+	if targetSize <= currentSize {
+		// The image already takes all the space the PV can provide within the toleration
+		return nil
+	}
+
+	preallocated, err := hdc.resizeImage(vmi, diskPath, currentSize, targetSize, hostDisk)
+	if err != nil || preallocated {
+		// A failed resize needs no space and a preallocated one is already accounted for by the filesystem
+		reservations.release(fsID, targetSize-allocated)
+	}
+	if err != nil {
+		log.Log.Reason(err).Errorf("Couldn't resize the disk image %s: %v", diskPath, err)
 		return err
 	}
+	hdc.removeChecksum(diskPath)
+	msg := fmt.Sprintf("Resized disk image %s from %d B to %d B", diskPath, currentSize, targetSize)
+	log.Log.Info(msg)
+	if err := hdc.notifier.SendK8sEvent(vmi, EventTypeHostDiskResized, EventReasonHostDiskResized, msg); err != nil {
+		log.Log.Reason(err).Warningf("Couldn't send k8s event for resized disk image: %v", err)
+	}
 	return nil
 }
 
+// resizeImage grows the disk image and reports whether the blocks of the new range were allocated on the filesystem
+func (hdc *DiskImgCreator) resizeImage(vmi *v1.VirtualMachineInstance, diskPath string, currentSize int64, size int64, hostDisk *v1.HostDisk) (bool, error) {
+	if hostDisk.Format == v1.HostDiskFormatQcow2 {
+		if output, err := hdc.resizeQcow2Func(diskPath, size); err != nil {
+			return false, fmt.Errorf("qemu-img failed with output '%s': %v", string(output), err)
+		}
+		return false, nil
+	}
+	if hdc.preallocation {
+		err := hdc.growPreallocatedFunc(diskPath, currentSize, size)
+		if err != syscall.EOPNOTSUPP {
+			return err == nil, err
+		}
+		msg := fmt.Sprintf("Preallocation is not supported for disk path %s, growing it sparse instead", diskPath)
+		log.Log.Info(msg)
+		if err := hdc.notifier.SendK8sEvent(vmi, EventTypePreallocationUnsupported, EventReasonPreallocationUnsupported, msg); err != nil {
+			log.Log.Reason(err).Warningf("Couldn't send k8s event for unsupported preallocation: %v", err)
+		}
+	}
+	// Growing a raw file keeps the new part sparse
+	return false, os.Truncate(diskPath, size)
+}
+
+// createImage creates the disk image and reports whether its blocks were allocated on the filesystem
+func (hdc *DiskImgCreator) createImage(vmi *v1.VirtualMachineInstance, diskPath string, size int64, hostDisk *v1.HostDisk) (bool, error) {
+	if hostDisk.Format == v1.HostDiskFormatQcow2 {
+		output, err := hdc.createQcow2Func(diskPath, size)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Couldn't create a qcow2 image for disk path: %s, error: %v", diskPath, err)
+			return false, fmt.Errorf("qemu-img failed with output '%s': %v", string(output), err)
+		}
+		return false, nil
+	}
+	if hdc.preallocation {
+		return hdc.createPreallocatedRaw(vmi, diskPath, size)
+	}
+	err := createSparseRaw(diskPath, size)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Couldn't create a sparse raw file for disk path: %s, error: %v", diskPath, err)
+		return false, err
+	}
+	return false, nil
+}
+
+func (hdc *DiskImgCreator) createPreallocatedRaw(vmi *v1.VirtualMachineInstance, diskPath string, size int64) (bool, error) {
+	err := hdc.preallocateFunc(diskPath, size)
+	if err == nil {
+		return true, nil
+	}
+	if err == syscall.EOPNOTSUPP {
+		msg := fmt.Sprintf("Preallocation is not supported for disk path %s, creating a sparse raw file instead", diskPath)
+		log.Log.Info(msg)
+		if err := hdc.notifier.SendK8sEvent(vmi, EventTypePreallocationUnsupported, EventReasonPreallocationUnsupported, msg); err != nil {
+			log.Log.Reason(err).Warningf("Couldn't send k8s event for unsupported preallocation: %v", err)
+		}
+		err = createSparseRaw(diskPath, size)
+	}
+	if err != nil {
+		log.Log.Reason(err).Errorf("Couldn't create a raw file for disk path: %s, error: %v", diskPath, err)
+		return false, err
+	}
+	return false, nil
+}
+
 func (hdc *DiskImgCreator) shrinkRequestedSize(vmi *v1.VirtualMachineInstance, requestedSize int64, availableSize int64, hostDisk *v1.HostDisk) (int64, error) {
 	// Some storage provisioners provide less space than requested, due to filesystem overhead etc.
 	// We tolerate some difference in requested and available capacity up to some degree.
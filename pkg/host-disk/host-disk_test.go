@@ -20,11 +20,19 @@
 package hostdisk
 
 import (
+	"encoding/binary"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math"
 	"os"
+	"os/exec"
 	"path"
+	"path/filepath"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -104,17 +112,20 @@ var _ = Describe("HostDisk", func() {
 		return file
 	}
 
+	markCreated := func(volumeName string) {
+		Expect(ioutil.WriteFile(path.Join(tempDir, volumeName, "disk.img"+createdMarkerSuffix), nil, 0640)).To(Succeed())
+	}
+
 	BeforeEach(func() {
 		var err error
 		tempDir, err = ioutil.TempDir("", "host-disk-images")
-		setDiskDirectory(tempDir)
 		Expect(err).NotTo(HaveOccurred())
 		notifier = MockNotifier{
 			Events: make(chan k8sv1.Event, 10),
 		}
 
-		hostDiskCreator = NewHostDiskCreator(notifier, 0, 0)
-		hostDiskCreatorWithReserve = NewHostDiskCreator(notifier, 10, 1048576)
+		hostDiskCreator = NewHostDiskCreator(notifier, HostDiskCreatorOptions{Concurrency: 1, PVCBaseDir: tempDir})
+		hostDiskCreatorWithReserve = NewHostDiskCreator(notifier, HostDiskCreatorOptions{LessPVCSpaceToleration: 10, MinimumPVCReserveBytes: 1048576, Concurrency: 1, PVCBaseDir: tempDir})
 	})
 
 	AfterEach(func() {
@@ -253,6 +264,43 @@ var _ = Describe("HostDisk", func() {
 					close(done)
 				}, 5)
 
+				It("Should report the size adjusted to the available space", func() {
+					vmi := v1.NewMinimalVMI("fake-vmi")
+					dirAvailable := uint64(60 << 20)
+
+					hostDiskCreatorWithReserve.dirBytesAvailableFunc = func(path string, reserve uint64) (uint64, error) {
+						return dirAvailable - reserve, nil
+					}
+					type sizeAdjustment struct {
+						volumeName           string
+						requested, available int64
+					}
+					var adjustments []sizeAdjustment
+					hostDiskCreatorWithReserve.SetOnSizeAdjusted(func(volumeName string, requested, available int64) {
+						adjustments = append(adjustments, sizeAdjustment{volumeName, requested, available})
+					})
+
+					addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+					Expect(hostDiskCreatorWithReserve.Create(vmi)).To(Succeed())
+
+					img1, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(adjustments).To(Equal([]sizeAdjustment{{"volume1", 67108864, img1.Size()}}))
+					Expect(img1.Size()).To(BeNumerically("==", dirAvailable-hostDiskCreatorWithReserve.minimumPVCReserveBytes))
+				})
+
+				It("Should not report a size adjustment if there is enough space", func() {
+					vmi := v1.NewMinimalVMI("fake-vmi")
+					var adjustedVolumes []string
+					hostDiskCreatorWithReserve.SetOnSizeAdjusted(func(volumeName string, requested, available int64) {
+						adjustedVolumes = append(adjustedVolumes, volumeName)
+					})
+
+					addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+					Expect(hostDiskCreatorWithReserve.Create(vmi)).To(Succeed())
+					Expect(adjustedVolumes).To(BeEmpty())
+				})
+
 				It("Should refuse to create disk image if reserve causes image to exceed lessPVCSpaceToleration", func(done Done) {
 					By("Creating a new minimal vmi")
 					vmi := v1.NewMinimalVMI("fake-vmi")
@@ -311,6 +359,11 @@ var _ = Describe("HostDisk", func() {
 
 					By("Executing CreateHostDisks func which should not create a disk.img")
 					hostDiskCreator.dirBytesAvailableFunc = fakeDirBytesAvailable
+					// every volume is backed by its own PV
+					fsIDs := map[string]uint64{"volume1": 1, "volume2": 2, "volume3": 3}
+					hostDiskCreator.filesystemIDFunc = func(dir string) (uint64, error) {
+						return fsIDs[path.Base(dir)], nil
+					}
 					err := hostDiskCreator.Create(vmi)
 					Expect(err).To(HaveOccurred())
 
@@ -338,6 +391,285 @@ var _ = Describe("HostDisk", func() {
 
 			})
 		})
+		Context("With qcow2 format", func() {
+			It("Should create a qcow2 image with the requested virtual size", func() {
+				if _, err := exec.LookPath("qemu-img"); err != nil {
+					Skip("qemu-img is not available")
+				}
+
+				By("Creating a new minimal vmi")
+				vmi := v1.NewMinimalVMI("fake-vmi")
+
+				By("Adding a qcow2 HostDisk volume")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				vmi.Spec.Volumes[0].HostDisk.Format = v1.HostDiskFormatQcow2
+
+				By("Executing CreateHostDisks which should create a qcow2 disk.img")
+				err := hostDiskCreator.Create(vmi)
+				Expect(err).NotTo(HaveOccurred())
+
+				header := make([]byte, 32)
+				f, err := os.Open(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				defer f.Close()
+				_, err = io.ReadFull(f, header)
+				Expect(err).NotTo(HaveOccurred())
+
+				// the qcow2 header starts with "QFI\xfb" and stores the virtual size at offset 24
+				Expect(header[:4]).To(Equal([]byte{'Q', 'F', 'I', 0xfb}))
+				Expect(binary.BigEndian.Uint64(header[24:32])).To(Equal(uint64(67108864))) // 64Mi
+			})
+
+			It("Should pass the requested capacity to qemu-img", func() {
+				var createdPath string
+				var createdSize int64
+				hostDiskCreator.createQcow2Func = func(fullPath string, size int64) ([]byte, error) {
+					createdPath = fullPath
+					createdSize = size
+					return nil, nil
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				vmi.Spec.Volumes[0].HostDisk.Format = v1.HostDiskFormatQcow2
+
+				Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+				Expect(createdPath).To(Equal(vmi.Spec.Volumes[0].HostDisk.Path))
+				Expect(createdSize).To(Equal(int64(67108864))) // 64Mi
+			})
+
+			It("Should return the qemu-img output on failure", func() {
+				hostDiskCreator.createQcow2Func = func(fullPath string, size int64) ([]byte, error) {
+					return []byte("Could not create image"), fmt.Errorf("exit status 1")
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				vmi.Spec.Volumes[0].HostDisk.Format = v1.HostDiskFormatQcow2
+
+				err := hostDiskCreator.Create(vmi)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Could not create image"))
+			})
+
+			table.DescribeTable("Should take the image metadata into account when reserving space", func(format v1.HostDiskFormat, overhead uint64) {
+				var requestedReserve uint64
+				hostDiskCreatorWithReserve.dirBytesAvailableFunc = func(path string, reserve uint64) (uint64, error) {
+					requestedReserve = reserve
+					return 1 << 30, nil
+				}
+				hostDiskCreatorWithReserve.createQcow2Func = func(fullPath string, size int64) ([]byte, error) {
+					return nil, nil
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				vmi.Spec.Volumes[0].HostDisk.Format = format
+
+				Expect(hostDiskCreatorWithReserve.Create(vmi)).To(Succeed())
+				Expect(requestedReserve).To(Equal(hostDiskCreatorWithReserve.minimumPVCReserveBytes + overhead))
+			},
+				table.Entry("not for the default format", v1.HostDiskFormat(""), uint64(0)),
+				table.Entry("not for raw", v1.HostDiskFormatRaw, uint64(0)),
+				// 1024 clusters need one L2 table and one refcount block, plus header, L1 and refcount table
+				table.Entry("for qcow2", v1.HostDiskFormatQcow2, uint64(5*qcow2ClusterSize)),
+			)
+		})
+		Context("With concurrent creation", func() {
+			var concurrentHostDiskCreator DiskImgCreator
+
+			BeforeEach(func() {
+				concurrentHostDiskCreator = NewHostDiskCreator(notifier, HostDiskCreatorOptions{Concurrency: 2, PVCBaseDir: tempDir})
+				concurrentHostDiskCreator.dirBytesAvailableFunc = func(path string, reserve uint64) (uint64, error) {
+					return 100 << 20, nil
+				}
+			})
+
+			It("Should create the disk images in parallel", func() {
+				started := sync.WaitGroup{}
+				started.Add(2)
+				concurrentHostDiskCreator.createQcow2Func = func(fullPath string, size int64) ([]byte, error) {
+					started.Done()
+					// only returns once both images are being created at the same time
+					started.Wait()
+					return nil, nil
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "32Mi")
+				addHostDisk(vmi, "volume2", v1.HostDiskExistsOrCreate, "32Mi")
+				vmi.Spec.Volumes[0].HostDisk.Format = v1.HostDiskFormatQcow2
+				vmi.Spec.Volumes[1].HostDisk.Format = v1.HostDiskFormatQcow2
+
+				done := make(chan error)
+				go func() {
+					done <- concurrentHostDiskCreator.Create(vmi)
+				}()
+				Eventually(done, 5*time.Second).Should(Receive(BeNil()))
+			})
+
+			It("Should not over-commit the space of a shared filesystem", func() {
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				addHostDisk(vmi, "volume2", v1.HostDiskExistsOrCreate, "64Mi")
+
+				err := concurrentHostDiskCreator.Create(vmi)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("not enough space"))
+
+				created := 0
+				for _, volume := range vmi.Spec.Volumes {
+					if _, err := os.Stat(volume.HostDisk.Path); err == nil {
+						created++
+					}
+				}
+				Expect(created).To(Equal(1))
+			})
+
+			It("Should not limit volumes on different filesystems", func() {
+				concurrentHostDiskCreator.filesystemIDFunc = func(dir string) (uint64, error) {
+					return map[string]uint64{"volume1": 1, "volume2": 2}[path.Base(dir)], nil
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				addHostDisk(vmi, "volume2", v1.HostDiskExistsOrCreate, "64Mi")
+
+				Expect(concurrentHostDiskCreator.Create(vmi)).To(Succeed())
+				for _, volume := range vmi.Spec.Volumes {
+					img, err := os.Stat(volume.HostDisk.Path)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(img.Size()).To(Equal(int64(67108864))) // 64Mi
+				}
+			})
+		})
+		Context("With preallocation", func() {
+			var preallocatingHostDiskCreator DiskImgCreator
+
+			BeforeEach(func() {
+				preallocatingHostDiskCreator = NewHostDiskCreator(notifier, HostDiskCreatorOptions{Preallocation: true, Concurrency: 1, PVCBaseDir: tempDir})
+			})
+
+			allocatedBlocks := func(path string) int64 {
+				info, err := os.Stat(path)
+				Expect(err).NotTo(HaveOccurred())
+				return info.Sys().(*syscall.Stat_t).Blocks
+			}
+
+			It("Should allocate the blocks of the disk.img", func() {
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				addHostDisk(vmi, "volume2", v1.HostDiskExistsOrCreate, "64Mi")
+
+				By("Creating a sparse and a preallocated disk.img of the same size")
+				Expect(hostDiskCreator.Create(&v1.VirtualMachineInstance{Spec: v1.VirtualMachineInstanceSpec{Volumes: vmi.Spec.Volumes[:1]}})).To(Succeed())
+				Expect(preallocatingHostDiskCreator.Create(&v1.VirtualMachineInstance{Spec: v1.VirtualMachineInstanceSpec{Volumes: vmi.Spec.Volumes[1:]}})).To(Succeed())
+				Expect(notifier.Events).To(BeEmpty())
+
+				sparse, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				preallocated, err := os.Stat(vmi.Spec.Volumes[1].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(preallocated.Size()).To(Equal(sparse.Size()))
+
+				// stat reports the allocated space in 512 byte blocks
+				Expect(allocatedBlocks(vmi.Spec.Volumes[1].HostDisk.Path)).To(BeNumerically(">=", int64(67108864/512)))
+				Expect(allocatedBlocks(vmi.Spec.Volumes[0].HostDisk.Path)).To(BeNumerically("<", allocatedBlocks(vmi.Spec.Volumes[1].HostDisk.Path)))
+			})
+
+			It("Should fall back to a sparse disk.img if preallocation is not supported", func() {
+				preallocatingHostDiskCreator.preallocateFunc = func(fullPath string, size int64) error {
+					return syscall.EOPNOTSUPP
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+
+				Expect(preallocatingHostDiskCreator.Create(vmi)).To(Succeed())
+
+				img, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(img.Size()).To(Equal(int64(67108864))) // 64Mi
+
+				event := <-notifier.Events
+				Expect(event.Type).To(Equal(EventTypePreallocationUnsupported))
+				Expect(event.Reason).To(Equal(EventReasonPreallocationUnsupported))
+			})
+
+			It("Should fail on other preallocation errors", func() {
+				preallocatingHostDiskCreator.preallocateFunc = func(fullPath string, size int64) error {
+					return syscall.ENOSPC
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+
+				Expect(preallocatingHostDiskCreator.Create(vmi)).To(MatchError(syscall.ENOSPC))
+				Expect(notifier.Events).To(BeEmpty())
+			})
+
+			It("Should not leave a disk.img behind if fallocate runs out of space", func() {
+				preallocatingHostDiskCreator.preallocateFunc = func(fullPath string, size int64) error {
+					return preallocateRaw(fullPath, size, func(fd int, mode uint32, off int64, len int64) error {
+						return syscall.ENOSPC
+					})
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+
+				Expect(preallocatingHostDiskCreator.Create(vmi)).To(MatchError(syscall.ENOSPC))
+				_, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+
+				By("Preallocating the disk.img on the next start instead of growing an empty file")
+				preallocatingHostDiskCreator.preallocateFunc = createPreallocatedRaw
+				Expect(preallocatingHostDiskCreator.Create(vmi)).To(Succeed())
+				Expect(allocatedBlocks(vmi.Spec.Volumes[0].HostDisk.Path)).To(BeNumerically(">=", int64(67108864/512)))
+				Expect(notifier.Events).To(BeEmpty())
+			})
+
+			It("Should allocate the blocks of the grown part of the disk.img", func() {
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				Expect(preallocatingHostDiskCreator.Create(vmi)).To(Succeed())
+
+				vmi.Spec.Volumes[0].HostDisk.Capacity = resource.MustParse("128Mi")
+				Expect(preallocatingHostDiskCreator.Create(vmi)).To(Succeed())
+
+				img, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(img.Size()).To(Equal(int64(134217728))) // 128Mi
+				Expect(allocatedBlocks(vmi.Spec.Volumes[0].HostDisk.Path)).To(BeNumerically(">=", int64(134217728/512)))
+
+				event := <-notifier.Events
+				Expect(event.Reason).To(Equal(EventReasonHostDiskResized))
+			})
+
+			It("Should grow the disk.img sparse if preallocation is not supported", func() {
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				Expect(preallocatingHostDiskCreator.Create(vmi)).To(Succeed())
+
+				var grownFrom int64
+				preallocatingHostDiskCreator.growPreallocatedFunc = func(fullPath string, currentSize int64, size int64) error {
+					grownFrom = currentSize
+					return syscall.EOPNOTSUPP
+				}
+				vmi.Spec.Volumes[0].HostDisk.Capacity = resource.MustParse("128Mi")
+				Expect(preallocatingHostDiskCreator.Create(vmi)).To(Succeed())
+				Expect(grownFrom).To(Equal(int64(67108864)))
+
+				img, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(img.Size()).To(Equal(int64(134217728))) // 128Mi
+
+				event := <-notifier.Events
+				Expect(event.Reason).To(Equal(EventReasonPreallocationUnsupported))
+				event = <-notifier.Events
+				Expect(event.Reason).To(Equal(EventReasonHostDiskResized))
+			})
+		})
 		Context("With existing disk.img", func() {
 			It("Should not re-create disk.img", func() {
 				By("Creating a disk.img before adding a HostDisk volume")
@@ -346,10 +678,10 @@ var _ = Describe("HostDisk", func() {
 				By("Creating a new minimal vmi")
 				vmi := v1.NewMinimalVMI("fake-vmi")
 
-				By("Adding a HostDisk volume")
-				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "128Mi")
+				By("Adding a HostDisk volume smaller than the disk.img")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "32Mi")
 
-				By("Executing CreateHostDisks which should not create a disk.img")
+				By("Executing CreateHostDisks which should neither create nor shrink a disk.img")
 				err := hostDiskCreator.Create(vmi)
 				Expect(err).NotTo(HaveOccurred())
 
@@ -362,7 +694,417 @@ var _ = Describe("HostDisk", func() {
 				// check if img has the same size as before
 				Expect(tmpDiskImg.Size()).NotTo(Equal(specSize))
 				Expect(tmpDiskImg.Size()).To(Equal(int64(67108864)))
+				Expect(hostDiskImg.Size()).To(Equal(int64(67108864)))
+			})
+
+			It("Should not resize a disk.img of the requested size", func() {
+				tmpDiskImg := createTempDiskImg("volume1")
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+
+				Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+
+				hostDiskImg, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostDiskImg.ModTime()).To(Equal(tmpDiskImg.ModTime()))
+				Expect(hostDiskImg.Size()).To(Equal(int64(67108864)))
+				Expect(notifier.Events).To(BeEmpty())
 			})
+
+			It("Should grow a disk.img smaller than requested", func() {
+				createTempDiskImg("volume1")
+				markCreated("volume1")
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "128Mi")
+
+				Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+
+				hostDiskImg, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostDiskImg.Size()).To(Equal(int64(134217728))) // 128Mi
+
+				event := <-notifier.Events
+				Expect(event.Type).To(Equal(EventTypeHostDiskResized))
+				Expect(event.Reason).To(Equal(EventReasonHostDiskResized))
+				Expect(event.Message).To(ContainSubstring("from 67108864 B to 134217728 B"))
+			})
+
+			It("Should not grow a disk.img provided by the user", func() {
+				tmpDiskImg := createTempDiskImg("volume1")
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "128Mi")
+
+				Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+
+				hostDiskImg, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostDiskImg.ModTime()).To(Equal(tmpDiskImg.ModTime()))
+				Expect(hostDiskImg.Size()).To(Equal(int64(67108864))) // 64Mi
+				Expect(notifier.Events).To(BeEmpty())
+			})
+
+			It("Should not grow a disk.img if there is not enough space", func() {
+				createTempDiskImg("volume1")
+				markCreated("volume1")
+				hostDiskCreator.dirBytesAvailableFunc = func(path string, reserve uint64) (uint64, error) {
+					return 32 << 20, nil
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "128Mi")
+
+				Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+
+				hostDiskImg, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostDiskImg.Size()).To(Equal(int64(67108864))) // 64Mi
+
+				event := <-notifier.Events
+				Expect(event.Type).To(Equal(EventTypeHostDiskNotResized))
+				Expect(event.Reason).To(Equal(EventReasonHostDiskNotResized))
+			})
+
+			It("Should not grow a disk.img created smaller within the toleration on the next start", func() {
+				dirAvailable := uint64(62 << 20)
+				hostDiskCreatorWithReserve.dirBytesAvailableFunc = func(dir string, reserve uint64) (uint64, error) {
+					// the sparse disk.img only takes the space of its allocated blocks on the PV
+					allocated, err := allocatedBytes(path.Join(dir, "disk.img"))
+					if err != nil && !os.IsNotExist(err) {
+						return 0, err
+					}
+					return dirAvailable - reserve - uint64(allocated), nil
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+
+				By("Creating the disk.img smaller than requested")
+				Expect(hostDiskCreatorWithReserve.Create(vmi)).To(Succeed())
+				event := <-notifier.Events
+				Expect(event.Reason).To(Equal(EventReasonToleratedSmallPV))
+				createdImg, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createdImg.Size()).To(BeNumerically("<", 67108864))
+
+				By("Starting again with the same PV")
+				Expect(hostDiskCreatorWithReserve.Create(vmi)).To(Succeed())
+
+				hostDiskImg, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostDiskImg.Size()).To(Equal(createdImg.Size()))
+				Expect(notifier.Events).To(BeEmpty())
+			})
+
+			It("Should grow a disk.img only up to the space of the PV", func() {
+				createTempDiskImg("volume1")
+				markCreated("volume1")
+				hostDiskCreatorWithReserve.dirBytesAvailableFunc = func(path string, reserve uint64) (uint64, error) {
+					return 124 << 20, nil
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "128Mi")
+
+				Expect(hostDiskCreatorWithReserve.Create(vmi)).To(Succeed())
+
+				hostDiskImg, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostDiskImg.Size()).To(BeNumerically(">=", 124<<20))
+				Expect(hostDiskImg.Size()).To(BeNumerically("<", 128<<20))
+
+				event := <-notifier.Events
+				Expect(event.Reason).To(Equal(EventReasonHostDiskResized))
+			})
+
+			It("Should grow a qcow2 disk.img with qemu-img", func() {
+				Expect(os.Mkdir(path.Join(tempDir, "volume1"), 0755)).To(Succeed())
+				// a qcow2 header with a virtual size of 64Mi
+				header := make([]byte, 32)
+				copy(header, []byte{'Q', 'F', 'I', 0xfb})
+				binary.BigEndian.PutUint64(header[24:32], 67108864)
+				Expect(ioutil.WriteFile(path.Join(tempDir, "volume1", "disk.img"), header, 0644)).To(Succeed())
+				markCreated("volume1")
+
+				var resizedSize int64
+				hostDiskCreator.resizeQcow2Func = func(fullPath string, size int64) ([]byte, error) {
+					resizedSize = size
+					return nil, nil
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "128Mi")
+				vmi.Spec.Volumes[0].HostDisk.Format = v1.HostDiskFormatQcow2
+
+				Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+				Expect(resizedSize).To(Equal(int64(134217728))) // 128Mi
+
+				event := <-notifier.Events
+				Expect(event.Reason).To(Equal(EventReasonHostDiskResized))
+			})
+		})
+	})
+
+	Describe("HostDisk creators with different base directories", func() {
+		It("Should create the disk images within their own base directory concurrently", func() {
+			baseDirs := []string{path.Join(tempDir, "base1"), path.Join(tempDir, "base2")}
+			done := make(chan error, len(baseDirs))
+			for _, baseDir := range baseDirs {
+				Expect(os.MkdirAll(path.Join(baseDir, "volume1"), 0755)).To(Succeed())
+				creator := NewHostDiskCreator(notifier, HostDiskCreatorOptions{Concurrency: 1, PVCBaseDir: baseDir})
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "32Mi")
+				go func() {
+					done <- creator.Create(vmi)
+				}()
+			}
+			for range baseDirs {
+				Eventually(done, 5*time.Second).Should(Receive(BeNil()))
+			}
+
+			for _, baseDir := range baseDirs {
+				Expect(path.Join(baseDir, "volume1", "disk.img")).To(BeAnExistingFile())
+			}
+			Expect(path.Join(tempDir, "volume1", "disk.img")).ToNot(BeAnExistingFile())
+		})
+
+		It("Should resolve the mounted paths within the base directory of the creator", func() {
+			creator := NewHostDiskCreator(notifier, HostDiskCreatorOptions{PVCBaseDir: "/base"})
+
+			Expect(creator.GetMountedHostDiskDir("volume1")).To(Equal("/base/volume1"))
+			Expect(creator.GetMountedHostDiskPath("volume1", "/some/where/disk.img")).To(Equal("/base/volume1/disk.img"))
+		})
+
+		It("Should default to the default disk directory and concurrency", func() {
+			creator := NewHostDiskCreator(notifier, HostDiskCreatorOptions{})
+
+			Expect(creator.GetMountedHostDiskDir("volume1")).To(Equal(path.Join(DefaultPVCBaseDir, "volume1")))
+			Expect(creator.GetMountedHostDiskDir("volume1")).To(Equal(GetMountedHostDiskDir("volume1")))
+			Expect(creator.concurrency).To(Equal(DefaultCreateConcurrency))
+		})
+
+		It("Should replace a PVC by a HostDisk within the base directory of the creator", func() {
+			baseDir := path.Join(tempDir, "base1")
+			Expect(os.MkdirAll(path.Join(baseDir, "volume1"), 0755)).To(Succeed())
+			creator := NewHostDiskCreator(notifier, HostDiskCreatorOptions{PVCBaseDir: baseDir})
+
+			mode := k8sv1.PersistentVolumeFilesystem
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "volume1",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "madeup"},
+				},
+			}}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+				Name:                      "volume1",
+				PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{VolumeMode: &mode},
+			}}
+
+			Expect(creator.ReplacePVCByHostDisk(vmi)).To(Succeed())
+			Expect(vmi.Spec.Volumes[0].HostDisk).NotTo(BeNil())
+			Expect(vmi.Spec.Volumes[0].HostDisk.Path).To(Equal(path.Join(baseDir, "volume1", "disk.img")))
+		})
+	})
+
+	Describe("HostDisk checksums", func() {
+		var checksummingHostDiskCreator DiskImgCreator
+
+		BeforeEach(func() {
+			checksummingHostDiskCreator = NewHostDiskCreator(notifier, HostDiskCreatorOptions{Concurrency: 1, RecordChecksums: true, PVCBaseDir: tempDir})
+		})
+
+		It("Should record the checksum of a created disk.img", func() {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+
+			Expect(checksummingHostDiskCreator.Create(vmi)).To(Succeed())
+
+			content, err := ioutil.ReadFile(vmi.Spec.Volumes[0].HostDisk.Path + ".sha256")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(HaveSuffix("  disk.img\n"))
+			Expect(checksummingHostDiskCreator.VerifyHostDiskChecksum("volume1")).To(BeTrue())
+		})
+
+		It("Should only record the size of a blank raw disk.img until it is verified", func() {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+			Expect(checksummingHostDiskCreator.Create(vmi)).To(Succeed())
+			checksumFile := vmi.Spec.Volumes[0].HostDisk.Path + ".sha256"
+
+			content, err := ioutil.ReadFile(checksumFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(HavePrefix("blank:"))
+
+			Expect(checksummingHostDiskCreator.VerifyHostDiskChecksum("volume1")).To(BeTrue())
+
+			content, err = ioutil.ReadFile(checksumFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).NotTo(HavePrefix("blank:"))
+			Expect(checksummingHostDiskCreator.VerifyHostDiskChecksum("volume1")).To(BeTrue())
+		})
+
+		It("Should fail the verification of a tampered disk.img", func() {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+			Expect(checksummingHostDiskCreator.Create(vmi)).To(Succeed())
+
+			f, err := os.OpenFile(vmi.Spec.Volumes[0].HostDisk.Path, os.O_WRONLY, 0)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = f.WriteAt([]byte("corrupted"), 1<<20)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+
+			Expect(checksummingHostDiskCreator.VerifyHostDiskChecksum("volume1")).To(BeFalse())
+		})
+
+		It("Should not record the checksum of an existing disk.img", func() {
+			createTempDiskImg("volume1")
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+
+			Expect(checksummingHostDiskCreator.Create(vmi)).To(Succeed())
+
+			_, err := checksummingHostDiskCreator.VerifyHostDiskChecksum("volume1")
+			Expect(err).To(MatchError(ContainSubstring("no checksum recorded")))
+		})
+
+		It("Should not record the checksum of a shared disk.img", func() {
+			shared := true
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+			vmi.Spec.Volumes[0].HostDisk.Shared = &shared
+
+			Expect(checksummingHostDiskCreator.Create(vmi)).To(Succeed())
+
+			_, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path + ".sha256")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("Should match the checksum of the blank disk.img without reading it", func() {
+			for _, size := range []int64{1, 4096, 67108864 + 1} {
+				diskPath := path.Join(tempDir, fmt.Sprintf("blank-%d.img", size))
+				Expect(createSparseRaw(diskPath, size)).To(Succeed())
+
+				expected, err := fileChecksum(diskPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blankRawChecksum(size)).To(Equal(expected))
+			}
+		})
+
+		It("Should drop the checksum of a resized disk.img", func() {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+			Expect(checksummingHostDiskCreator.Create(vmi)).To(Succeed())
+			Expect(vmi.Spec.Volumes[0].HostDisk.Path + ".sha256").To(BeAnExistingFile())
+
+			vmi.Spec.Volumes[0].HostDisk.Capacity = resource.MustParse("128Mi")
+			Expect(checksummingHostDiskCreator.Create(vmi)).To(Succeed())
+
+			_, err := checksummingHostDiskCreator.VerifyHostDiskChecksum("volume1")
+			Expect(err).To(MatchError(ContainSubstring("no checksum recorded")))
+		})
+	})
+
+	Describe("HostDisk with a path outside of the disk directory", func() {
+		var outsideDir string
+
+		BeforeEach(func() {
+			var err error
+			outsideDir, err = ioutil.TempDir("", "host-disk-outside")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(outsideDir)
+		})
+
+		newHostDiskVMI := func(volumeName, diskPath string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: volumeName,
+				VolumeSource: v1.VolumeSource{
+					HostDisk: &v1.HostDisk{
+						Path:     diskPath,
+						Type:     v1.HostDiskExistsOrCreate,
+						Capacity: resource.MustParse("64Mi"),
+					},
+				},
+			})
+			return vmi
+		}
+
+		expectOutsideDirEmpty := func() {
+			files, err := ioutil.ReadDir(outsideDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(BeEmpty())
+		}
+
+		It("Should only use the file name of the HostDisk path", func() {
+			vmi := newHostDiskVMI("volume1", "../../etc/x")
+			Expect(os.Mkdir(path.Join(tempDir, "volume1"), 0755)).To(Succeed())
+
+			Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+
+			_, err := os.Stat(path.Join(tempDir, "volume1", "x"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should refuse a volume name escaping the disk directory", func() {
+			relOutsideDir, err := filepath.Rel(tempDir, outsideDir)
+			Expect(err).NotTo(HaveOccurred())
+			vmi := newHostDiskVMI(relOutsideDir, "disk.img")
+
+			err = hostDiskCreator.Create(vmi)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("which is not within"))
+			expectOutsideDirEmpty()
+		})
+
+		It("Should refuse a symlinked volume directory", func() {
+			Expect(os.Symlink(outsideDir, path.Join(tempDir, "volume1"))).To(Succeed())
+			vmi := newHostDiskVMI("volume1", "disk.img")
+
+			err := hostDiskCreator.Create(vmi)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("which is not within"))
+			expectOutsideDirEmpty()
+		})
+
+		It("Should refuse a symlinked disk image", func() {
+			Expect(os.Mkdir(path.Join(tempDir, "volume1"), 0755)).To(Succeed())
+			Expect(os.Symlink(path.Join(outsideDir, "disk.img"), path.Join(tempDir, "volume1", "disk.img"))).To(Succeed())
+			vmi := newHostDiskVMI("volume1", "disk.img")
+
+			Expect(hostDiskCreator.Create(vmi)).NotTo(Succeed())
+			expectOutsideDirEmpty()
+		})
+
+		It("Should only refuse a PVC in a symlinked volume directory when its HostDisk is created", func() {
+			Expect(os.Symlink(outsideDir, path.Join(tempDir, "volume1"))).To(Succeed())
+			mode := k8sv1.PersistentVolumeFilesystem
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "volume1",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "madeup"},
+				},
+			}}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+				Name:                      "volume1",
+				PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{VolumeMode: &mode},
+			}}
+
+			Expect(hostDiskCreator.ReplacePVCByHostDisk(vmi)).To(Succeed())
+			Expect(vmi.Spec.Volumes[0].HostDisk).NotTo(BeNil())
+
+			err := hostDiskCreator.Create(vmi)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("which is not within"))
+			expectOutsideDirEmpty()
 		})
 	})
 
@@ -384,6 +1126,14 @@ var _ = Describe("HostDisk", func() {
 		})
 	})
 
+	Describe("Available space of the disk directory", func() {
+		It("Should not wrap around when the reserve exceeds the available space", func() {
+			available, err := dirBytesAvailable(tempDir, math.MaxUint64)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(available).To(BeZero())
+		})
+	})
+
 	Describe("VMI with PVC volume", func() {
 
 		var virtClient *kubecli.MockKubevirtClient
@@ -440,7 +1190,7 @@ var _ = Describe("HostDisk", func() {
 			}
 
 			By("Replacing PVCs with hostdisks")
-			ReplacePVCByHostDisk(vmi)
+			Expect(hostDiskCreator.ReplacePVCByHostDisk(vmi)).To(Succeed())
 
 			Expect(len(vmi.Spec.Volumes)).To(Equal(1), "There should still be 1 volume")
 
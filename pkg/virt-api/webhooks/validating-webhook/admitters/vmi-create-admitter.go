@@ -1952,6 +1952,23 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 				})
 			}
 
+			if hostDisk.Format != "" && hostDisk.Format != v1.HostDiskFormatRaw && hostDisk.Format != v1.HostDiskFormatQcow2 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s' or '%s'", field.Index(idx).Child("hostDisk", "format").String(), hostDisk.Format, v1.HostDiskFormatRaw, v1.HostDiskFormatQcow2),
+					Field:   field.Index(idx).Child("hostDisk", "format").String(),
+				})
+			}
+
+			// the format is only known for disk images created on the cluster
+			if hostDisk.Format != "" && hostDisk.Type != v1.HostDiskExistsOrCreate {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s is allowed to pass only with %s equal to '%s'", field.Index(idx).Child("hostDisk", "format").String(), field.Index(idx).Child("hostDisk", "type").String(), v1.HostDiskExistsOrCreate),
+					Field:   field.Index(idx).Child("hostDisk", "format").String(),
+				})
+			}
+
 			// if disk.img already exists and user knows that by specifying type 'Disk' it is pointless to set capacity
 			if hostDisk.Type == v1.HostDiskExists && !hostDisk.Capacity.IsZero() {
 				causes = append(causes, metav1.StatusCause{
@@ -3199,6 +3199,35 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(BeEmpty())
 		})
 
+		table.DescribeTable("should validate the hostDisk format", func(hostDiskType v1.HostDiskType, format v1.HostDiskFormat, expectedCauses int) {
+			enableFeatureGate(virtconfig.HostDiskGate)
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testHostDisk",
+				VolumeSource: v1.VolumeSource{
+					HostDisk: &v1.HostDisk{
+						Type:   hostDiskType,
+						Path:   "/hostdisktest.img",
+						Format: format,
+					},
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake[0].hostDisk.format"))
+			}
+		},
+			table.Entry("and accept the default format", v1.HostDiskExistsOrCreate, v1.HostDiskFormat(""), 0),
+			table.Entry("and accept raw", v1.HostDiskExistsOrCreate, v1.HostDiskFormatRaw, 0),
+			table.Entry("and accept qcow2", v1.HostDiskExistsOrCreate, v1.HostDiskFormatQcow2, 0),
+			table.Entry("and reject an unknown format", v1.HostDiskExistsOrCreate, v1.HostDiskFormat("vmdk"), 1),
+			table.Entry("and accept no format for an existing disk image", v1.HostDiskExists, v1.HostDiskFormat(""), 0),
+			table.Entry("and reject a format for an existing disk image", v1.HostDiskExists, v1.HostDiskFormatQcow2, 1),
+		)
+
 		It("should accept sysprep volumes", func() {
 			vmi := v1.NewMinimalVMI("fake-vmi")
 			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
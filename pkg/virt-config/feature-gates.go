@@ -38,6 +38,7 @@ const (
 	SnapshotGate               = "Snapshot"
 	HotplugVolumesGate         = "HotplugVolumes"
 	HostDiskGate               = "HostDisk"
+	HostDiskChecksumsGate      = "HostDiskChecksums"
 	VirtIOFSGate               = "ExperimentalVirtiofsSupport"
 	MacvtapGate                = "Macvtap"
 	DownwardMetricsFeatureGate = "DownwardMetrics"
@@ -106,6 +107,10 @@ func (config *ClusterConfig) HostDiskEnabled() bool {
 	return config.isFeatureGateEnabled(HostDiskGate)
 }
 
+func (config *ClusterConfig) HostDiskChecksumsEnabled() bool {
+	return config.isFeatureGateEnabled(HostDiskChecksumsGate)
+}
+
 func (config *ClusterConfig) VirtiofsEnabled() bool {
 	return config.isFeatureGateEnabled(VirtIOFSGate)
 }
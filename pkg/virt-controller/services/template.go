@@ -1025,6 +1025,9 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		if nonRoot {
 			command = append(command, "--run-as-nonroot")
 		}
+		if t.clusterConfig.HostDiskChecksumsEnabled() {
+			command = append(command, "--record-host-disk-checksums")
+		}
 	}
 
 	useEmulation := t.clusterConfig.IsUseEmulation()
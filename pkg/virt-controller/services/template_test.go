@@ -2906,6 +2906,33 @@ var _ = Describe("Template", func() {
 			Expect(pod.Spec.Containers[0].Command).To(ContainElement("1048576"), "command arg value should be correct")
 		})
 
+		table.DescribeTable("should add the host disk checksums argument to the template", func(featureGate string, expected bool) {
+			config, kvInformer, svc = configFactory(defaultArch)
+			enableFeatureGate(featureGate)
+
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testvmi", Namespace: "default", UID: "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{Volumes: []v1.Volume{}, Domain: v1.DomainSpec{
+					Devices: v1.Devices{
+						DisableHotplug: true,
+					},
+				}},
+			}
+			pod, err := svc.RenderLaunchManifest(&vmi)
+			Expect(err).ToNot(HaveOccurred())
+
+			if expected {
+				Expect(pod.Spec.Containers[0].Command).To(ContainElement("--record-host-disk-checksums"))
+			} else {
+				Expect(pod.Spec.Containers[0].Command).ToNot(ContainElement("--record-host-disk-checksums"))
+			}
+		},
+			table.Entry("when the HostDiskChecksums feature gate is enabled", virtconfig.HostDiskChecksumsGate, true),
+			table.Entry("when only the HostDisk feature gate is enabled", virtconfig.HostDiskGate, false),
+		)
+
 		Context("with specified priorityClass", func() {
 			It("should add priorityClass", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
@@ -114,6 +114,7 @@ type ConverterContext struct {
 	MemBalloonStatsPeriod uint
 	UseVirtioTransitional bool
 	EphemeraldiskCreator  ephemeraldisk.EphemeralDiskCreatorInterface
+	HostDiskCreator       *hostdisk.DiskImgCreator
 	VolumesDiscardIgnore  []string
 	Topology              *cmdv1.Topology
 }
@@ -540,7 +541,7 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c *Convert
 	}
 
 	if source.HostDisk != nil {
-		return Convert_v1_HostDisk_To_api_Disk(source.Name, source.HostDisk.Path, disk)
+		return Convert_v1_HostDisk_To_api_Disk(source.Name, source.HostDisk, disk, c)
 	}
 
 	if source.PersistentVolumeClaim != nil {
@@ -713,11 +714,21 @@ func Convert_v1_Hotplug_BlockVolumeSource_To_api_Disk(volumeName string, disk *a
 	return nil
 }
 
-func Convert_v1_HostDisk_To_api_Disk(volumeName string, path string, disk *api.Disk) error {
+func Convert_v1_HostDisk_To_api_Disk(volumeName string, hostDisk *v1.HostDisk, disk *api.Disk, c *ConverterContext) error {
+	if hostDisk.Format != "" && hostDisk.Type != v1.HostDiskExistsOrCreate {
+		return fmt.Errorf("hostDisk %s can only set a format if it is of type %s", volumeName, v1.HostDiskExistsOrCreate)
+	}
 	disk.Type = "file"
 	disk.Driver.Type = "raw"
+	if hostDisk.Format == v1.HostDiskFormatQcow2 {
+		disk.Driver.Type = "qcow2"
+	}
 	disk.Driver.ErrorPolicy = "stop"
-	disk.Source.File = hostdisk.GetMountedHostDiskPath(volumeName, path)
+	if c.HostDiskCreator != nil {
+		disk.Source.File = c.HostDiskCreator.GetMountedHostDiskPath(volumeName, hostDisk.Path)
+	} else {
+		disk.Source.File = hostdisk.GetMountedHostDiskPath(volumeName, hostDisk.Path)
+	}
 	return nil
 }
 
@@ -41,6 +41,7 @@ import (
 	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"kubevirt.io/kubevirt/pkg/ephemeral-disk/fake"
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 
@@ -2677,6 +2678,35 @@ var _ = Describe("Converter", func() {
 			Expect(*(domain.Spec.Devices.Disks[0].Driver.Queues)).To(Equal(expectedQueues),
 				"expected number of queues to equal number of requested vCPUs")
 		})
+
+		table.DescribeTable("should set the driver type of a HostDisk", func(format v1.HostDiskFormat, driverType string) {
+			vmi.Spec.Volumes[0].HostDisk.Format = format
+
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, SMBios: &cmdv1.SMBios{}})
+			Expect(domain.Spec.Devices.Disks[0].Type).To(Equal("file"))
+			Expect(domain.Spec.Devices.Disks[0].Driver.Type).To(Equal(driverType))
+			Expect(domain.Spec.Devices.Disks[0].Source.File).To(Equal("/var/run/kubevirt-private/vmi-disks/mydisk/disk.img"))
+		},
+			table.Entry("to raw by default", v1.HostDiskFormat(""), "raw"),
+			table.Entry("to raw for the raw format", v1.HostDiskFormatRaw, "raw"),
+			table.Entry("to qcow2 for the qcow2 format", v1.HostDiskFormatQcow2, "qcow2"),
+		)
+
+		It("should reject a HostDisk format if the disk image is not created on the cluster", func() {
+			vmi.Spec.Volumes[0].HostDisk.Type = v1.HostDiskExists
+			vmi.Spec.Volumes[0].HostDisk.Capacity = resource.Quantity{}
+			vmi.Spec.Volumes[0].HostDisk.Format = v1.HostDiskFormatQcow2
+
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, &ConverterContext{UseEmulation: true, SMBios: &cmdv1.SMBios{}})).ToNot(Succeed())
+		})
+
+		It("should resolve the HostDisk path within the base directory of the host disk creator", func() {
+			hostDiskCreator := hostdisk.NewHostDiskCreator(nil, hostdisk.HostDiskCreatorOptions{PVCBaseDir: "/custom/vmi-disks"})
+
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, SMBios: &cmdv1.SMBios{}, HostDiskCreator: &hostDiskCreator})
+			Expect(domain.Spec.Devices.Disks[0].Source.File).To(Equal("/custom/vmi-disks/mydisk/disk.img"))
+		})
 	})
 	Context("Correctly handle iothreads with dedicated cpus", func() {
 		var vmi *v1.VirtualMachineInstance
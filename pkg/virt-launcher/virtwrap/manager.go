@@ -124,7 +124,6 @@ type LibvirtDomainManager struct {
 
 	virtShareDir             string
 	notifier                 *eventsclient.Notifier
-	lessPVCSpaceToleration   int
 	paused                   pausedVMIs
 	agentData                *agentpoller.AsyncAgentStore
 	cloudInitDataStore       *cloudinit.CloudInitData
@@ -133,7 +132,7 @@ type LibvirtDomainManager struct {
 	ovmfPath                 string
 	networkCacheStoreFactory cache.InterfaceCacheFactory
 	ephemeralDiskCreator     ephemeraldisk.EphemeralDiskCreatorInterface
-	minimumPVCReserveBytes   uint64
+	hostDiskCreator          hostdisk.DiskImgCreator
 	directIOChecker          converter.DirectIOChecker
 }
 
@@ -165,17 +164,16 @@ func (s pausedVMIs) contains(uid types.UID) bool {
 	return ok
 }
 
-func NewLibvirtDomainManager(connection cli.Connection, virtShareDir string, notifier *eventsclient.Notifier, lessPVCSpaceToleration int, minimumPVCReserveBytes uint64, agentStore *agentpoller.AsyncAgentStore, ovmfPath string, ephemeralDiskCreator ephemeraldisk.EphemeralDiskCreatorInterface) (DomainManager, error) {
+func NewLibvirtDomainManager(connection cli.Connection, virtShareDir string, notifier *eventsclient.Notifier, lessPVCSpaceToleration int, minimumPVCReserveBytes uint64, recordHostDiskChecksums bool, agentStore *agentpoller.AsyncAgentStore, ovmfPath string, ephemeralDiskCreator ephemeraldisk.EphemeralDiskCreatorInterface) (DomainManager, error) {
 	directIOChecker := converter.NewDirectIOChecker()
-	return newLibvirtDomainManager(connection, virtShareDir, notifier, lessPVCSpaceToleration, minimumPVCReserveBytes, agentStore, ovmfPath, ephemeralDiskCreator, directIOChecker)
+	return newLibvirtDomainManager(connection, virtShareDir, notifier, lessPVCSpaceToleration, minimumPVCReserveBytes, recordHostDiskChecksums, agentStore, ovmfPath, ephemeralDiskCreator, directIOChecker)
 }
 
-func newLibvirtDomainManager(connection cli.Connection, virtShareDir string, notifier *eventsclient.Notifier, lessPVCSpaceToleration int, minimumPVCReserveBytes uint64, agentStore *agentpoller.AsyncAgentStore, ovmfPath string, ephemeralDiskCreator ephemeraldisk.EphemeralDiskCreatorInterface, directIOChecker converter.DirectIOChecker) (DomainManager, error) {
+func newLibvirtDomainManager(connection cli.Connection, virtShareDir string, notifier *eventsclient.Notifier, lessPVCSpaceToleration int, minimumPVCReserveBytes uint64, recordHostDiskChecksums bool, agentStore *agentpoller.AsyncAgentStore, ovmfPath string, ephemeralDiskCreator ephemeraldisk.EphemeralDiskCreatorInterface, directIOChecker converter.DirectIOChecker) (DomainManager, error) {
 	manager := LibvirtDomainManager{
-		virConn:                connection,
-		virtShareDir:           virtShareDir,
-		notifier:               notifier,
-		lessPVCSpaceToleration: lessPVCSpaceToleration,
+		virConn:      connection,
+		virtShareDir: virtShareDir,
+		notifier:     notifier,
 		paused: pausedVMIs{
 			paused: make(map[types.UID]bool, 0),
 		},
@@ -183,10 +181,15 @@ func newLibvirtDomainManager(connection cli.Connection, virtShareDir string, not
 		efiEnvironment:           efi.DetectEFIEnvironment(runtime.GOARCH, ovmfPath),
 		networkCacheStoreFactory: cache.NewInterfaceCacheFactory(),
 		ephemeralDiskCreator:     ephemeralDiskCreator,
-		minimumPVCReserveBytes:   minimumPVCReserveBytes,
 		directIOChecker:          directIOChecker,
 	}
 	manager.credManager = accesscredentials.NewManager(connection, &manager.domainModifyLock)
+	// The converter resolves the paths of the host disk images with the same creator
+	manager.hostDiskCreator = hostdisk.NewHostDiskCreator(notifier, hostdisk.HostDiskCreatorOptions{
+		LessPVCSpaceToleration: lessPVCSpaceToleration,
+		MinimumPVCReserveBytes: minimumPVCReserveBytes,
+		RecordChecksums:        recordHostDiskChecksums,
+	})
 
 	return &manager, nil
 }
@@ -477,8 +480,7 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 
 	// create disks images on the cluster lever
 	// or initialize disks images for empty PVC
-	hostDiskCreator := hostdisk.NewHostDiskCreator(l.notifier, l.lessPVCSpaceToleration, l.minimumPVCReserveBytes)
-	err = hostDiskCreator.Create(vmi)
+	err = l.hostDiskCreator.Create(vmi)
 	if err != nil {
 		return domain, fmt.Errorf("preparing host-disks failed: %v", err)
 	}
@@ -717,6 +719,7 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 		UseVirtioTransitional: vmi.Spec.Domain.Devices.UseVirtioTransitional != nil && *vmi.Spec.Domain.Devices.UseVirtioTransitional,
 		PermanentVolumes:      permanentVolumes,
 		EphemeraldiskCreator:  l.ephemeralDiskCreator,
+		HostDiskCreator:       &l.hostDiskCreator,
 	}
 
 	if options != nil {
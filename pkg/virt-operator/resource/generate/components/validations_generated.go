@@ -5102,6 +5102,11 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Capacity of the sparse disk
                             pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                             x-kubernetes-int-or-string: true
+                          format:
+                            description: Format of the disk image created on the cluster,
+                              only allowed with type 'DiskOrCreate' allowed options
+                              are 'raw' and 'qcow2', defaults to 'raw'
+                            type: string
                           path:
                             description: The path to HostDisk image located on the
                               cluster
@@ -7720,6 +7725,11 @@ var CRDsValidation map[string]string = map[string]string{
                     description: Capacity of the sparse disk
                     pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                     x-kubernetes-int-or-string: true
+                  format:
+                    description: Format of the disk image created on the cluster,
+                      only allowed with type 'DiskOrCreate' allowed options are 'raw'
+                      and 'qcow2', defaults to 'raw'
+                    type: string
                   path:
                     description: The path to HostDisk image located on the cluster
                     type: string
@@ -11532,6 +11542,11 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Capacity of the sparse disk
                             pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                             x-kubernetes-int-or-string: true
+                          format:
+                            description: Format of the disk image created on the cluster,
+                              only allowed with type 'DiskOrCreate' allowed options
+                              are 'raw' and 'qcow2', defaults to 'raw'
+                            type: string
                           path:
                             description: The path to HostDisk image located on the
                               cluster
@@ -15097,6 +15112,12 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: Capacity of the sparse disk
                                         pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                                         x-kubernetes-int-or-string: true
+                                      format:
+                                        description: Format of the disk image created
+                                          on the cluster, only allowed with type 'DiskOrCreate'
+                                          allowed options are 'raw' and 'qcow2', defaults
+                                          to 'raw'
+                                        type: string
                                       path:
                                         description: The path to HostDisk image located
                                           on the cluster
@@ -21,7 +21,7 @@ func VersionCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 		Args:    templates.ExactArgs("version", 0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			v := Version{clientConfig: clientConfig}
-			return v.Run()
+			return v.Run(cmd)
 		},
 	}
 	cmd.Flags().BoolVarP(&clientOnly, "client", "c", clientOnly, "Client version only (no server required).")
@@ -39,8 +39,8 @@ type Version struct {
 	clientConfig clientcmd.ClientConfig
 }
 
-func (v *Version) Run() error {
-	fmt.Printf("Client Version: %s\n", fmt.Sprintf("%#v", version.Get()))
+func (v *Version) Run(cmd *cobra.Command) error {
+	fmt.Fprintf(cmd.OutOrStdout(), "Client Version: %s\n", fmt.Sprintf("%#v", version.Get()))
 
 	if !clientOnly {
 		virCli, err := kubecli.GetKubevirtClientFromClientConfig(v.clientConfig)
@@ -53,7 +53,7 @@ func (v *Version) Run() error {
 			return err
 		}
 
-		fmt.Printf("Server Version: %s\n", fmt.Sprintf("%#v", *serverInfo))
+		fmt.Fprintf(cmd.OutOrStdout(), "Server Version: %s\n", fmt.Sprintf("%#v", *serverInfo))
 	}
 
 	return nil
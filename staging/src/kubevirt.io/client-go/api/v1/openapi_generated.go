@@ -20915,6 +20915,13 @@ func schema_kubevirtio_client_go_api_v1_HostDisk(ref common.ReferenceCallback) c
 							Format:      "",
 						},
 					},
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format of the disk image created on the cluster, only allowed with type 'DiskOrCreate' allowed options are 'raw' and 'qcow2', defaults to 'raw'",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"path", "type"},
 			},
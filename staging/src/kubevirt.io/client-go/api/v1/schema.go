@@ -55,6 +55,10 @@ type HostDisk struct {
 	Capacity resource.Quantity `json:"capacity,omitempty"`
 	// Shared indicate whether the path is shared between nodes
 	Shared *bool `json:"shared,omitempty"`
+	// Format of the disk image created on the cluster, only allowed with type 'DiskOrCreate'
+	// allowed options are 'raw' and 'qcow2', defaults to 'raw'
+	// +optional
+	Format HostDiskFormat `json:"format,omitempty"`
 }
 
 // ConfigMapVolumeSource adapts a ConfigMap into a volume.
@@ -1376,6 +1376,17 @@ const (
 	HostDiskExists HostDiskType = "Disk"
 )
 
+//
+// +k8s:openapi-gen=true
+type HostDiskFormat string
+
+const (
+	// the disk image is a sparse raw file
+	HostDiskFormatRaw HostDiskFormat = "raw"
+	// the disk image is a qcow2 file created with qemu-img
+	HostDiskFormatQcow2 HostDiskFormat = "qcow2"
+)
+
 //
 // +k8s:openapi-gen=true
 type NetworkInterfaceType string
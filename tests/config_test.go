@@ -139,6 +139,17 @@ var _ = Describe("[Serial][rfe_id:899][crit:medium][vendor:cnv-qe@redhat.com][le
 					&expect.BExp{R: expectedOutput},
 				}, 200)).To(Succeed())
 			})
+
+			It("should expose the ConfigMap keys as files in the guest", func() {
+				vmi := tests.NewRandomVMIWithConfigMap(configMapName)
+				tests.RunVMIAndExpectLaunch(vmi, 90)
+
+				Expect(tests.ExpectConfigMapInGuest(vmi, console.LoginToAlpine, "/mnt", map[string]string{
+					"option1": "value1",
+					"option2": "value2",
+					"option3": "value3",
+				})).To(Succeed())
+			})
 		})
 
 		Context("With multiple volumes", func() {
@@ -232,6 +243,16 @@ var _ = Describe("[Serial][rfe_id:899][crit:medium][vendor:cnv-qe@redhat.com][le
 					&expect.BExp{R: expectedOutput},
 				}, 200)).To(Succeed())
 			})
+
+			It("should expose the Secret keys as files in the guest", func() {
+				vmi := tests.NewRandomVMIWithSecret(secretName)
+				tests.RunVMIAndExpectLaunch(vmi, 90)
+
+				Expect(tests.ExpectSecretInGuest(vmi, console.LoginToAlpine, "/mnt", map[string]string{
+					"user":     "admin",
+					"password": "redhat",
+				})).To(Succeed())
+			})
 		})
 
 		Context("With multiple volumes", func() {
@@ -582,5 +603,18 @@ var _ = Describe("[Serial][rfe_id:899][crit:medium][vendor:cnv-qe@redhat.com][le
 				&expect.BExp{R: expectedOutput},
 			}, 200*time.Second)).To(Succeed())
 		})
+
+		It("should expose the VMI labels on the downward API disk", func() {
+			vmi := tests.NewRandomVMIWithPVC(tests.DiskAlpineHostPath)
+			if vmi.ObjectMeta.Labels == nil {
+				vmi.ObjectMeta.Labels = map[string]string{}
+			}
+			vmi.ObjectMeta.Labels[testLabelKey] = testLabelVal
+			tests.AddLabelDownwardAPIVolume(vmi, downwardAPIName)
+
+			vmi = tests.RunVMIAndExpectLaunch(vmi, 90)
+
+			Expect(tests.ExpectDownwardAPILabels(vmi, console.LoginToAlpine, "/mnt", map[string]string{testLabelKey: testLabelVal})).To(Succeed())
+		})
 	})
 })
@@ -101,6 +101,50 @@ func SafeExpectBatchWithResponse(vmi *v1.VirtualMachineInstance, expected []expe
 	return resp, err
 }
 
+// SafeExpectCommandOutput runs the command line from `command` connecting to an already logged in console at vmi
+// and returns what the command printed, see `ExpectCommandOutput`.
+func SafeExpectCommandOutput(vmi *v1.VirtualMachineInstance, command string, wait int) (string, error) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		panic(err)
+	}
+	expecter, _, err := NewExpecter(virtClient, vmi, 30*time.Second)
+	if err != nil {
+		return "", err
+	}
+	defer expecter.Close()
+
+	return ExpectCommandOutput(expecter, command, time.Second*time.Duration(wait))
+}
+
+// ExpectCommandOutput sends the command line from `command` to an already logged in console
+// and waits `timeout` for the prompt to return.
+// The returned output holds the lines printed by the command, without the echoed command line and the prompt.
+func ExpectCommandOutput(expecter expect.Expecter, command string, timeout time.Duration) (string, error) {
+	res, err := ExpectBatchWithValidatedSend(expecter, []expect.Batcher{
+		&expect.BSnd{S: command + "\n"},
+		&expect.BExp{R: PromptExpression},
+	}, timeout)
+	if err != nil {
+		return "", err
+	}
+	return commandOutput(res[0].Output, command), nil
+}
+
+// commandOutput cuts everything up to the echoed command line and the trailing prompt line
+// from the console output read until the prompt returned
+func commandOutput(output string, command string) string {
+	idx := strings.Index(output, command)
+	if idx < 0 {
+		return ""
+	}
+	lines := strings.Split(strings.ReplaceAll(output[idx+len(command):], "\r", ""), "\n")
+	if len(lines) < 3 {
+		return ""
+	}
+	return strings.Join(lines[1:len(lines)-1], "\n")
+}
+
 // RunCommand runs the command line from `command connecting to an already logged in console at vmi
 // and wait `timeout` for command to return.
 // NOTE: The safer version `ExpectBatchWithValidatedSend` is not used here since it does not support cases.
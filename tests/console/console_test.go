@@ -0,0 +1,64 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package console
+
+import (
+	"time"
+
+	expect "github.com/google/goexpect"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Console", func() {
+
+	const prompt = "[fedora@testvmi ~]$ "
+
+	table.DescribeTable("should return the output of a command", func(command, printed, expected string) {
+		expecter, _, err := expect.SpawnFake([]expect.Batcher{
+			&expect.BSnd{S: CRLF + prompt},
+			&expect.BExp{R: command + "\n"},
+			&expect.BSnd{S: command + CRLF + printed + prompt},
+		}, 5*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		defer expecter.Close()
+
+		output, err := ExpectCommandOutput(expecter, command, 5*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(output).To(Equal(expected))
+	},
+		table.Entry("with a single line", "nproc", "4"+CRLF, "4"),
+		table.Entry("with several lines", "cat /etc/hostname /etc/hostname", "testvmi"+CRLF+"testvmi"+CRLF, "testvmi\ntestvmi"),
+		table.Entry("without any line", "true", "", ""),
+	)
+
+	It("should fail if the prompt does not return", func() {
+		expecter, _, err := expect.SpawnFake([]expect.Batcher{
+			&expect.BExp{R: "sleep 10\n"},
+			&expect.BSnd{S: "sleep 10" + CRLF},
+		}, 5*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		defer expecter.Close()
+
+		_, err = ExpectCommandOutput(expecter, "sleep 10", 100*time.Millisecond)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -95,6 +95,12 @@ var _ = Describe("[rfe_id:127][posneg:negative][crit:medium][vendor:cnv-qe@redha
 						"login as 'cirros' user",
 					)
 				})
+
+				It("should answer on the serial console subresource", func() {
+					vmi := tests.NewRandomVMIWithEphemeralDiskAndUserdata(cd.ContainerDiskFor(cd.ContainerDiskCirros), "#!/bin/bash\necho 'hello'\n")
+					RunVMIAndWaitForStart(vmi)
+					Expect(tests.ExpectSerialConsoleConnectable(vmi)).To(Succeed())
+				})
 			})
 
 			Context("with a fedora image", func() {
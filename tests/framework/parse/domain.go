@@ -0,0 +1,67 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package parse
+
+import (
+	launcherApi "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// DomainMachineType returns the machine type the domain is emulated with
+func DomainMachineType(domSpec *launcherApi.DomainSpec) string {
+	return domSpec.OS.Type.Machine
+}
+
+// DomainHyperVFeatures returns the state of the Hyper-V enlightenments present in the domain, keyed by their VMI API names
+func DomainHyperVFeatures(domSpec *launcherApi.DomainSpec) map[string]bool {
+	features := map[string]bool{}
+	if domSpec.Features == nil || domSpec.Features.Hyperv == nil {
+		return features
+	}
+
+	hyperv := domSpec.Features.Hyperv
+	states := map[string]*launcherApi.FeatureState{
+		"relaxed":         hyperv.Relaxed,
+		"vapic":           hyperv.VAPIC,
+		"vpindex":         hyperv.VPIndex,
+		"runtime":         hyperv.Runtime,
+		"synic":           hyperv.SyNIC,
+		"reset":           hyperv.Reset,
+		"frequencies":     hyperv.Frequencies,
+		"reenlightenment": hyperv.Reenlightenment,
+		"tlbflush":        hyperv.TLBFlush,
+		"ipi":             hyperv.IPI,
+		"evmcs":           hyperv.EVMCS,
+	}
+	for name, state := range states {
+		if state != nil {
+			features[name] = state.State == "on"
+		}
+	}
+	if hyperv.Spinlocks != nil {
+		features["spinlocks"] = hyperv.Spinlocks.State == "on"
+	}
+	if hyperv.SyNICTimer != nil {
+		features["synictimer"] = hyperv.SyNICTimer.State == "on"
+	}
+	if hyperv.VendorID != nil {
+		features["vendorid"] = hyperv.VendorID.State == "on"
+	}
+	return features
+}
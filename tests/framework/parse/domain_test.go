@@ -0,0 +1,74 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package parse
+
+import (
+	"encoding/xml"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	launcherApi "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const domainXML = `<domain type="kvm">
+  <name>kubevirt-test-default_testvmi</name>
+  <memory unit="b">134217728</memory>
+  <os>
+    <type arch="x86_64" machine="pc-q35-rhel8.4.0">hvm</type>
+  </os>
+  <features>
+    <acpi/>
+    <hyperv>
+      <relaxed state="on"/>
+      <vapic state="off"/>
+      <spinlocks state="on" retries="8191"/>
+      <stimer state="on"/>
+    </hyperv>
+  </features>
+</domain>`
+
+var _ = Describe("Domain spec", func() {
+
+	var domSpec *launcherApi.DomainSpec
+
+	BeforeEach(func() {
+		domSpec = &launcherApi.DomainSpec{}
+		Expect(xml.Unmarshal([]byte(domainXML), domSpec)).To(Succeed())
+	})
+
+	It("should return the emulated machine type", func() {
+		Expect(DomainMachineType(domSpec)).To(Equal("pc-q35-rhel8.4.0"))
+	})
+
+	It("should return the state of the Hyper-V features", func() {
+		Expect(DomainHyperVFeatures(domSpec)).To(Equal(map[string]bool{
+			"relaxed":    true,
+			"vapic":      false,
+			"spinlocks":  true,
+			"synictimer": true,
+		}))
+	})
+
+	It("should return no Hyper-V features if the domain has none", func() {
+		domSpec.Features = nil
+		Expect(DomainHyperVFeatures(domSpec)).To(BeEmpty())
+	})
+})
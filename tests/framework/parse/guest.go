@@ -0,0 +1,136 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package parse
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// NSLookupAddresses returns the addresses listed after the Name line of the nslookup output,
+// skipping the addresses of the DNS server itself
+func NSLookupAddresses(output string) ([]string, error) {
+	var addresses []string
+	nameFound := false
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "Name:" {
+			nameFound = true
+			continue
+		}
+		if !nameFound || !strings.HasPrefix(fields[0], "Address") {
+			continue
+		}
+		// busybox prints "Address 1: <ip> <name>", bind prints "Address: <ip>"
+		for _, field := range fields[1:] {
+			if net.ParseIP(field) != nil {
+				addresses = append(addresses, field)
+				break
+			}
+		}
+	}
+	if !nameFound {
+		return nil, fmt.Errorf("failed to resolve name: %q", output)
+	}
+	return addresses, nil
+}
+
+// LSCPUTopology extracts the number of sockets, cores per socket and threads per core from lscpu output
+func LSCPUTopology(output string) (sockets, cores, threads int, err error) {
+	fields := map[string]*int{
+		"Socket(s)":          &sockets,
+		"Core(s) per socket": &cores,
+		"Thread(s) per core": &threads,
+	}
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if field, exists := fields[strings.TrimSpace(parts[0])]; exists {
+			if *field, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil {
+				return 0, 0, 0, fmt.Errorf("failed to parse lscpu line %q: %v", line, err)
+			}
+		}
+	}
+	if sockets == 0 || cores == 0 || threads == 0 {
+		return 0, 0, 0, fmt.Errorf("no CPU topology found in the lscpu output: %s", output)
+	}
+	return sockets, cores, threads, nil
+}
+
+// DefaultRouteGateway returns the gateway of the default route listed in the ip route output
+func DefaultRouteGateway(routes string) (string, error) {
+	for _, line := range strings.Split(routes, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[0] == "default" && fields[1] == "via" {
+			return fields[2], nil
+		}
+	}
+	return "", fmt.Errorf("no default route found in %q", routes)
+}
+
+// TCPPortListening looks for a socket bound to port in the LISTEN state (0A) in the /proc/net/tcp output
+func TCPPortListening(procNetTCP string, port int) bool {
+	localPortSuffix := fmt.Sprintf(":%04X", port)
+	for _, line := range strings.Split(procNetTCP, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		if strings.HasSuffix(fields[1], localPortSuffix) && fields[3] == "0A" {
+			return true
+		}
+	}
+	return false
+}
+
+// DownwardAPILabels parses the key="value" lines of a downward API labels file
+func DownwardAPILabels(content string) map[string]string {
+	labels := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value, err := strconv.Unquote(parts[1])
+		if err != nil {
+			continue
+		}
+		labels[parts[0]] = value
+	}
+	return labels
+}
+
+// HugepageSizeKB extracts the hugepage size from /proc/meminfo output
+func HugepageSizeKB(output string) (int, error) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "Hugepagesize:" && fields[2] == "kB" {
+			return strconv.Atoi(fields[1])
+		}
+	}
+	return 0, fmt.Errorf("no hugepage size found in the meminfo output: %s", output)
+}
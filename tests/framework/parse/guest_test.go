@@ -0,0 +1,138 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package parse
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Guest output", func() {
+
+	Context("of nslookup", func() {
+		table.DescribeTable("should return the resolved addresses", func(output string, expected []string) {
+			Expect(NSLookupAddresses(output)).To(Equal(expected))
+		},
+			table.Entry("from busybox nslookup",
+				"Server:    10.96.0.10\nAddress 1: 10.96.0.10 kube-dns.kube-system.svc.cluster.local\n\n"+
+					"Name:      kubernetes.default\nAddress 1: 10.96.0.1 kubernetes.default.svc.cluster.local",
+				[]string{"10.96.0.1"}),
+			table.Entry("from bind nslookup",
+				"Server:\t\t10.96.0.10\nAddress:\t10.96.0.10#53\n\n"+
+					"Name:\tkubernetes.default.svc.cluster.local\nAddress: 10.96.0.1",
+				[]string{"10.96.0.1"}),
+			table.Entry("with several addresses",
+				"Server:    10.96.0.10\nAddress 1: 10.96.0.10\n\n"+
+					"Name:      dual.example.com\nAddress 1: 10.0.0.1\nAddress 2: fd00::1",
+				[]string{"10.0.0.1", "fd00::1"}),
+		)
+
+		It("should fail if the name could not be resolved", func() {
+			_, err := NSLookupAddresses("Server:    10.96.0.10\nAddress 1: 10.96.0.10\n\nnslookup: can't resolve 'nonexistent'")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("of lscpu", func() {
+		It("should return the CPU topology", func() {
+			output := "Architecture:        x86_64\n" +
+				"CPU(s):              4\n" +
+				"Thread(s) per core:  1\n" +
+				"Core(s) per socket:  2\n" +
+				"Socket(s):           2\n" +
+				"Model name:          Intel Core Processor (Skylake, IBRS)"
+			sockets, cores, threads, err := LSCPUTopology(output)
+			Expect(err).ToNot(HaveOccurred())
+			Expect([]int{sockets, cores, threads}).To(Equal([]int{2, 2, 1}))
+		})
+
+		table.DescribeTable("should fail", func(output string) {
+			_, _, _, err := LSCPUTopology(output)
+			Expect(err).To(HaveOccurred())
+		},
+			table.Entry("if lscpu is missing", "-sh: lscpu: not found"),
+			table.Entry("if the topology is incomplete", "CPU(s):              4\nSocket(s):           2"),
+			table.Entry("if a count is not a number", "Thread(s) per core:  one\nCore(s) per socket:  2\nSocket(s):           2"),
+		)
+	})
+
+	Context("of ip route", func() {
+		table.DescribeTable("should return the gateway of the default route", func(routes, expected string) {
+			Expect(DefaultRouteGateway(routes)).To(Equal(expected))
+		},
+			table.Entry("listed first", "default via 10.0.2.1 dev eth0\n10.0.2.0/24 dev eth0 scope link  src 10.0.2.2", "10.0.2.1"),
+			table.Entry("listed after other routes", "10.0.2.0/24 dev eth0 scope link  src 10.0.2.2\ndefault via 10.0.2.1 dev eth0 metric 100", "10.0.2.1"),
+		)
+
+		table.DescribeTable("should fail", func(routes string) {
+			_, err := DefaultRouteGateway(routes)
+			Expect(err).To(HaveOccurred())
+		},
+			table.Entry("if there is no default route", "10.0.2.0/24 dev eth0 scope link  src 10.0.2.2"),
+			table.Entry("if the default route has no gateway", "default dev eth0 scope link"),
+		)
+	})
+
+	Context("of /proc/net/tcp", func() {
+		const procNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000   107        0 31283 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:1F90 0100007F:A2C4 01 00000000:00000000 00:00000000 00000000   107        0 31284 1 0000000000000000 20 4 30 10 -1`
+
+		table.DescribeTable("should detect", func(port int, listening bool) {
+			Expect(TCPPortListening(procNetTCP, port)).To(Equal(listening))
+		},
+			table.Entry("a listening port", 80, true),
+			table.Entry("a port with an established connection only", 8080, false),
+			table.Entry("a port not in use", 443, false),
+		)
+	})
+
+	Context("of a downward API labels file", func() {
+		It("should return the quoted label values", func() {
+			content := "kubevirt.io/created-by=\"1234\"\nkubevirt.io.testdownwardapi=\"downwardAPIValue\""
+			Expect(DownwardAPILabels(content)).To(Equal(map[string]string{
+				"kubevirt.io/created-by":      "1234",
+				"kubevirt.io.testdownwardapi": "downwardAPIValue",
+			}))
+		})
+
+		table.DescribeTable("should skip", func(content string) {
+			Expect(DownwardAPILabels(content)).To(BeEmpty())
+		},
+			table.Entry("lines which are not labels", "cat: can't open '/mnt/labels': No such file or directory"),
+			table.Entry("label values which are not quoted", "kubevirt.io/created-by=1234"),
+		)
+	})
+
+	Context("of /proc/meminfo", func() {
+		It("should return the hugepage size", func() {
+			Expect(HugepageSizeKB("MemTotal:         999964 kB\nHugepagesize:       2048 kB\n")).To(Equal(2048))
+		})
+
+		table.DescribeTable("should fail", func(output string) {
+			_, err := HugepageSizeKB(output)
+			Expect(err).To(HaveOccurred())
+		},
+			table.Entry("if meminfo is missing", "grep: /proc/meminfo: No such file or directory"),
+			table.Entry("if the hugepage size is not in kB", "Hugepagesize:       2048 MB"),
+		)
+	})
+})
@@ -0,0 +1,111 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package parse extracts what the functional tests check from the output collected
+// on the cluster, such as guest command output, domain specs and Prometheus responses.
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	promv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+var metricLabelRegex = regexp.MustCompile(`(\w+)="((?:[^"\\]|\\.)*)"`)
+
+// VMIMetrics parses metrics in the Prometheus text exposition format and returns the series
+// whose namespace and name labels match the VMI
+func VMIMetrics(text, namespace, name string) (map[string]float64, error) {
+	metrics := map[string]float64{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		series, rest := line, ""
+		if idx := strings.LastIndex(line, "}"); idx >= 0 {
+			series, rest = line[:idx+1], line[idx+1:]
+		} else if idx := strings.Index(line, " "); idx >= 0 {
+			series, rest = line[:idx], line[idx:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("metric line without value: %s", line)
+		}
+
+		labels := map[string]string{}
+		for _, match := range metricLabelRegex.FindAllStringSubmatch(series, -1) {
+			labels[match[1]] = match[2]
+		}
+		if labels["namespace"] != namespace || labels["name"] != name {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the value of metric %s: %v", series, err)
+		}
+		metrics[series] = value
+	}
+	return metrics, nil
+}
+
+// AlertDefined tells whether one of the rules defines the alert, recording rules are not alerts
+func AlertDefined(rules []promv1.PrometheusRule, name string) bool {
+	for _, rule := range rules {
+		for _, group := range rule.Spec.Groups {
+			for _, r := range group.Rules {
+				if r.Alert == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// AlertActive checks whether a response of the Prometheus alerts API lists the alert.
+// The API only returns active alerts, which are either pending or firing.
+func AlertActive(body []byte, alertName string) (bool, error) {
+	var response struct {
+		Status string `json:"status"`
+		Data   struct {
+			Alerts []struct {
+				Labels map[string]string `json:"labels"`
+			} `json:"alerts"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return false, err
+	}
+	if response.Status != "success" {
+		return false, fmt.Errorf("prometheus alerts query failed with status %s", response.Status)
+	}
+	for _, alert := range response.Data.Alerts {
+		if alert.Labels["alertname"] == alertName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
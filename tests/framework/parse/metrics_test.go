@@ -0,0 +1,122 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package parse
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	promv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+var _ = Describe("Metrics", func() {
+
+	Context("of a VMI", func() {
+		const metricsText = `# HELP kubevirt_vmi_memory_resident_bytes resident set size of the process running the domain.
+# TYPE kubevirt_vmi_memory_resident_bytes gauge
+kubevirt_vmi_memory_resident_bytes{domain="default_testvmi",name="testvmi",namespace="default",node="node01"} 2.09715200e+08
+kubevirt_vmi_memory_resident_bytes{domain="default_othervmi",name="othervmi",namespace="default",node="node01"} 1.048576e+08
+kubevirt_vmi_storage_read_traffic_bytes_total{drive="vda",name="testvmi",namespace="default",node="node01"} 4096
+kubevirt_vmi_storage_read_traffic_bytes_total{drive="vda",name="testvmi",namespace="other",node="node01"} 512
+go_goroutines 42
+`
+
+		table.DescribeTable("should return the series of the VMI", func(namespace, name string, expected map[string]float64) {
+			metrics, err := VMIMetrics(metricsText, namespace, name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(metrics).To(Equal(expected))
+		},
+			table.Entry("with several series", "default", "testvmi", map[string]float64{
+				`kubevirt_vmi_memory_resident_bytes{domain="default_testvmi",name="testvmi",namespace="default",node="node01"}`: 209715200,
+				`kubevirt_vmi_storage_read_traffic_bytes_total{drive="vda",name="testvmi",namespace="default",node="node01"}`:   4096,
+			}),
+			table.Entry("in another namespace", "other", "testvmi", map[string]float64{
+				`kubevirt_vmi_storage_read_traffic_bytes_total{drive="vda",name="testvmi",namespace="other",node="node01"}`: 512,
+			}),
+			table.Entry("without any series", "default", "missingvmi", map[string]float64{}),
+		)
+
+		table.DescribeTable("should fail", func(text string) {
+			_, err := VMIMetrics(text, "default", "testvmi")
+			Expect(err).To(HaveOccurred())
+		},
+			table.Entry("on a malformed value", `kubevirt_vmi_memory_resident_bytes{name="testvmi",namespace="default"} NaNish`),
+			table.Entry("on a missing value", `kubevirt_vmi_memory_resident_bytes{name="testvmi",namespace="default"}`),
+		)
+	})
+	Context("of Prometheus rules", func() {
+		rules := []promv1.PrometheusRule{
+			{
+				Spec: promv1.PrometheusRuleSpec{
+					Groups: []promv1.RuleGroup{
+						{
+							Name: "kubevirt.rules",
+							Rules: []promv1.Rule{
+								{Record: "num_of_running_virt_api_servers"},
+								{Alert: "VirtAPIDown"},
+							},
+						},
+					},
+				},
+			},
+			{
+				Spec: promv1.PrometheusRuleSpec{
+					Groups: []promv1.RuleGroup{
+						{Name: "empty.rules"},
+						{
+							Name:  "handler.rules",
+							Rules: []promv1.Rule{{Alert: "VirtHandlerDaemonSetRolloutFailing"}},
+						},
+					},
+				},
+			},
+		}
+
+		table.DescribeTable("should tell whether an alert is defined", func(name string, defined bool) {
+			Expect(AlertDefined(rules, name)).To(Equal(defined))
+		},
+			table.Entry("for an alert of the first rule", "VirtAPIDown", true),
+			table.Entry("for an alert of another rule", "VirtHandlerDaemonSetRolloutFailing", true),
+			table.Entry("for a recording rule", "num_of_running_virt_api_servers", false),
+			table.Entry("for an unknown alert", "VirtControllerDown", false),
+		)
+	})
+	Context("of the Prometheus alerts API", func() {
+		const virtAPIDown = `{"status":"success","data":{"alerts":[` +
+			`{"labels":{"alertname":"VirtAPIDown","severity":"critical"},"state":"firing","activeAt":"2021-06-01T10:00:00Z","value":"0e+00"}]}}`
+
+		table.DescribeTable("should tell whether an alert is active", func(body, name string, active bool) {
+			Expect(AlertActive([]byte(body), name)).To(Equal(active))
+		},
+			table.Entry("for a listed alert", virtAPIDown, "VirtAPIDown", true),
+			table.Entry("for an alert which is not listed", virtAPIDown, "VirtControllerDown", false),
+			table.Entry("without any active alert", `{"status":"success","data":{"alerts":[]}}`, "VirtAPIDown", false),
+		)
+
+		table.DescribeTable("should fail", func(body string) {
+			_, err := AlertActive([]byte(body), "VirtAPIDown")
+			Expect(err).To(HaveOccurred())
+		},
+			table.Entry("on an unsuccessful query", `{"status":"error","errorType":"timeout"}`),
+			table.Entry("on a malformed response", `<html>Bad Gateway</html>`),
+		)
+	})
+})
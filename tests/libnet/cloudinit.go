@@ -102,6 +102,13 @@ func WithDHCP6Enabled() NetworkDataInterfaceOption {
 	}
 }
 
+func WithGateway4(gateway4 string) NetworkDataInterfaceOption {
+	return func(networkDataInterface *CloudInitInterface) error {
+		networkDataInterface.Gateway4 = gateway4
+		return nil
+	}
+}
+
 func WithGateway6(gateway6 string) NetworkDataInterfaceOption {
 	return func(networkDataInterface *CloudInitInterface) error {
 		networkDataInterface.Gateway6 = gateway6
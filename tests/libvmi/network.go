@@ -39,6 +39,25 @@ func WithNetwork(network *kvirtv1.Network) Option {
 	}
 }
 
+// WithMasqueradeCIDR sets the IPv4 and IPv6 CIDRs of the pod network backing the masquerade interface,
+// it has to follow the options adding the interface and the network.
+// The masquerade CIDRs are configured on the pod network source, since the masquerade binding itself has no fields.
+func WithMasqueradeCIDR(cidr, cidrIPv6 string) Option {
+	return func(vmi *kvirtv1.VirtualMachineInstance) {
+		for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+			if iface.Masquerade == nil {
+				continue
+			}
+			for i, network := range vmi.Spec.Networks {
+				if network.Name == iface.Name && network.Pod != nil {
+					vmi.Spec.Networks[i].Pod.VMNetworkCIDR = cidr
+					vmi.Spec.Networks[i].Pod.VMIPv6NetworkCIDR = cidrIPv6
+				}
+			}
+		}
+	}
+}
+
 // InterfaceDeviceWithMasqueradeBinding returns an Interface named "default" with masquerade binding.
 func InterfaceDeviceWithMasqueradeBinding(ports ...kvirtv1.Port) kvirtv1.Interface {
 	return kvirtv1.Interface{
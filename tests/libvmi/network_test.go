@@ -0,0 +1,53 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package libvmi
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kvirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Network options", func() {
+
+	Context("with masquerade CIDRs", func() {
+		It("should set them on the pod network of the masquerade interface", func() {
+			vmi := New("testvmi",
+				WithInterface(InterfaceDeviceWithMasqueradeBinding()),
+				WithNetwork(kvirtv1.DefaultPodNetwork()),
+				WithMasqueradeCIDR("10.10.10.0/24", "fd10:10:10::/120"),
+			)
+			Expect(vmi.Spec.Networks).To(HaveLen(1))
+			Expect(vmi.Spec.Networks[0].Pod.VMNetworkCIDR).To(Equal("10.10.10.0/24"))
+			Expect(vmi.Spec.Networks[0].Pod.VMIPv6NetworkCIDR).To(Equal("fd10:10:10::/120"))
+		})
+
+		It("should not touch the pod network of other interfaces", func() {
+			vmi := New("testvmi",
+				WithInterface(InterfaceDeviceWithBridgeBinding()),
+				WithNetwork(kvirtv1.DefaultPodNetwork()),
+				WithMasqueradeCIDR("10.10.10.0/24", "fd10:10:10::/120"),
+			)
+			Expect(vmi.Spec.Networks[0].Pod.VMNetworkCIDR).To(BeEmpty())
+			Expect(vmi.Spec.Networks[0].Pod.VMIPv6NetworkCIDR).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,89 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package libvmi
+
+import (
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kvirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+// WithPodAffinity requires the VMI to be scheduled on a node running a pod with the given label.
+func WithPodAffinity(labelKey, labelValue string) Option {
+	return func(vmi *kvirtv1.VirtualMachineInstance) {
+		if vmi.Spec.Affinity == nil {
+			vmi.Spec.Affinity = &k8sv1.Affinity{}
+		}
+		if vmi.Spec.Affinity.PodAffinity == nil {
+			vmi.Spec.Affinity.PodAffinity = &k8sv1.PodAffinity{}
+		}
+		vmi.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			vmi.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			newHostnamePodAffinityTerm(labelKey, labelValue),
+		)
+	}
+}
+
+// WithPodAntiAffinity requires the VMI to be scheduled on a node not running a pod with the given label.
+func WithPodAntiAffinity(labelKey, labelValue string) Option {
+	return func(vmi *kvirtv1.VirtualMachineInstance) {
+		if vmi.Spec.Affinity == nil {
+			vmi.Spec.Affinity = &k8sv1.Affinity{}
+		}
+		if vmi.Spec.Affinity.PodAntiAffinity == nil {
+			vmi.Spec.Affinity.PodAntiAffinity = &k8sv1.PodAntiAffinity{}
+		}
+		vmi.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			vmi.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			newHostnamePodAffinityTerm(labelKey, labelValue),
+		)
+	}
+}
+
+// WithToleration allows the VMI to be scheduled on nodes tainted with the given key
+// and effect, regardless of the taint value.
+func WithToleration(key string, effect k8sv1.TaintEffect) Option {
+	return func(vmi *kvirtv1.VirtualMachineInstance) {
+		vmi.Spec.Tolerations = append(vmi.Spec.Tolerations, k8sv1.Toleration{
+			Key:      key,
+			Operator: k8sv1.TolerationOpExists,
+			Effect:   effect,
+		})
+	}
+}
+
+// WithPriorityClass sets the priority class the VMI is scheduled with.
+func WithPriorityClass(className string) Option {
+	return func(vmi *kvirtv1.VirtualMachineInstance) {
+		vmi.Spec.PriorityClassName = className
+	}
+}
+
+func newHostnamePodAffinityTerm(labelKey, labelValue string) k8sv1.PodAffinityTerm {
+	return k8sv1.PodAffinityTerm{
+		LabelSelector: &k8smetav1.LabelSelector{
+			MatchExpressions: []k8smetav1.LabelSelectorRequirement{
+				{Key: labelKey, Operator: k8smetav1.LabelSelectorOpIn, Values: []string{labelValue}},
+			},
+		},
+		TopologyKey: k8sv1.LabelHostname,
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package libvmi
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Scheduling options", func() {
+
+	Context("with pod affinity", func() {
+		expectHostnameTerm := func(term k8sv1.PodAffinityTerm) {
+			Expect(term.TopologyKey).To(Equal(k8sv1.LabelHostname))
+			Expect(term.LabelSelector).ToNot(BeNil())
+			Expect(term.LabelSelector.MatchExpressions).To(ConsistOf(k8smetav1.LabelSelectorRequirement{
+				Key:      "app",
+				Operator: k8smetav1.LabelSelectorOpIn,
+				Values:   []string{"server"},
+			}))
+		}
+
+		It("should add a required pod affinity term", func() {
+			vmi := New("testvmi", WithPodAffinity("app", "server"))
+			Expect(vmi.Spec.Affinity.PodAntiAffinity).To(BeNil())
+			Expect(vmi.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+			expectHostnameTerm(vmi.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0])
+		})
+
+		It("should add a required pod anti-affinity term", func() {
+			vmi := New("testvmi", WithPodAntiAffinity("app", "server"))
+			Expect(vmi.Spec.Affinity.PodAffinity).To(BeNil())
+			Expect(vmi.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+			expectHostnameTerm(vmi.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0])
+		})
+
+		It("should keep existing terms", func() {
+			vmi := New("testvmi", WithPodAntiAffinity("app", "server"), WithPodAntiAffinity("app", "server"))
+			Expect(vmi.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(2))
+		})
+	})
+
+	Context("with tolerations", func() {
+		It("should append a toleration for the taint key and effect", func() {
+			vmi := New("testvmi")
+			vmi.Spec.Tolerations = []k8sv1.Toleration{{Key: "existing", Operator: k8sv1.TolerationOpExists}}
+
+			WithToleration("dedicated", k8sv1.TaintEffectNoSchedule)(vmi)
+			Expect(vmi.Spec.Tolerations).To(HaveLen(2))
+			Expect(vmi.Spec.Tolerations[1]).To(Equal(k8sv1.Toleration{
+				Key:      "dedicated",
+				Operator: k8sv1.TolerationOpExists,
+				Effect:   k8sv1.TaintEffectNoSchedule,
+			}))
+		})
+	})
+
+	It("should set the priority class name", func() {
+		vmi := New("testvmi", WithPriorityClass("high-priority"))
+		Expect(vmi.Spec.PriorityClassName).To(Equal("high-priority"))
+	})
+})
@@ -20,6 +20,8 @@
 package libvmi
 
 import (
+	"fmt"
+
 	kvirtv1 "kubevirt.io/client-go/api/v1"
 )
 
@@ -32,6 +34,28 @@ func WithContainerImage(name string) Option {
 	}
 }
 
+// SetDiskBlockSize presents the named disk to the guest with the given custom logical and physical block sizes.
+func SetDiskBlockSize(vmi *kvirtv1.VirtualMachineInstance, diskName string, logical, physical uint) error {
+	for _, size := range []uint{logical, physical} {
+		if size == 0 || size&(size-1) != 0 {
+			return fmt.Errorf("block size %d is not a power of two", size)
+		}
+	}
+
+	for i := range vmi.Spec.Domain.Devices.Disks {
+		if vmi.Spec.Domain.Devices.Disks[i].Name == diskName {
+			vmi.Spec.Domain.Devices.Disks[i].BlockSize = &kvirtv1.BlockSize{
+				Custom: &kvirtv1.CustomBlockSize{
+					Logical:  logical,
+					Physical: physical,
+				},
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("disk %s not found in VMI %s", diskName, vmi.Name)
+}
+
 func addDisk(vmi *kvirtv1.VirtualMachineInstance, disk kvirtv1.Disk) {
 	if !diskExists(vmi, disk) {
 		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, disk)
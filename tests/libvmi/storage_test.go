@@ -0,0 +1,59 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package libvmi
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	kvirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Storage options", func() {
+
+	Context("with a custom disk block size", func() {
+		var vmi *kvirtv1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = New("testvmi", WithContainerImage("image"))
+		})
+
+		It("should set the custom block size on the disk", func() {
+			Expect(SetDiskBlockSize(vmi, "disk0", 512, 4096)).To(Succeed())
+			Expect(vmi.Spec.Domain.Devices.Disks[0].BlockSize).To(Equal(&kvirtv1.BlockSize{
+				Custom: &kvirtv1.CustomBlockSize{Logical: 512, Physical: 4096},
+			}))
+		})
+
+		It("should fail if the disk does not exist", func() {
+			Expect(SetDiskBlockSize(vmi, "disk1", 512, 4096)).ToNot(Succeed())
+		})
+
+		table.DescribeTable("should reject block sizes which are not a power of two", func(logical, physical uint) {
+			Expect(SetDiskBlockSize(vmi, "disk0", logical, physical)).ToNot(Succeed())
+			Expect(vmi.Spec.Domain.Devices.Disks[0].BlockSize).To(BeNil())
+		},
+			table.Entry("with an invalid logical size", uint(500), uint(4096)),
+			table.Entry("with an invalid physical size", uint(512), uint(4000)),
+			table.Entry("with a zero size", uint(0), uint(4096)),
+		)
+	})
+})
@@ -20,6 +20,8 @@
 package libvmi
 
 import (
+	"fmt"
+
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -91,6 +93,54 @@ func WithResourceMemory(value string) Option {
 	}
 }
 
+// WithEvictionStrategy sets the eviction strategy of the VMI.
+func WithEvictionStrategy(strategy kvirtv1.EvictionStrategy) Option {
+	return func(vmi *kvirtv1.VirtualMachineInstance) {
+		vmi.Spec.EvictionStrategy = &strategy
+	}
+}
+
+// WithHyperVFeatures enables the given Hyper-V enlightenments, named after their API fields.
+func WithHyperVFeatures(features ...string) Option {
+	return func(vmi *kvirtv1.VirtualMachineInstance) {
+		if vmi.Spec.Domain.Features == nil {
+			vmi.Spec.Domain.Features = &kvirtv1.Features{}
+		}
+		if vmi.Spec.Domain.Features.Hyperv == nil {
+			vmi.Spec.Domain.Features.Hyperv = &kvirtv1.FeatureHyperv{}
+		}
+
+		for _, feature := range features {
+			enable, known := hypervFeatureEnablers[feature]
+			if !known {
+				panic(fmt.Sprintf("unknown Hyper-V feature %s", feature))
+			}
+			enable(vmi.Spec.Domain.Features.Hyperv)
+		}
+	}
+}
+
+var hypervFeatureEnablers = map[string]func(hyperv *kvirtv1.FeatureHyperv){
+	"relaxed":         func(h *kvirtv1.FeatureHyperv) { h.Relaxed = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"vapic":           func(h *kvirtv1.FeatureHyperv) { h.VAPIC = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"spinlocks":       func(h *kvirtv1.FeatureHyperv) { h.Spinlocks = &kvirtv1.FeatureSpinlocks{Enabled: enabled()} },
+	"vpindex":         func(h *kvirtv1.FeatureHyperv) { h.VPIndex = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"runtime":         func(h *kvirtv1.FeatureHyperv) { h.Runtime = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"synic":           func(h *kvirtv1.FeatureHyperv) { h.SyNIC = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"synictimer":      func(h *kvirtv1.FeatureHyperv) { h.SyNICTimer = &kvirtv1.SyNICTimer{Enabled: enabled()} },
+	"reset":           func(h *kvirtv1.FeatureHyperv) { h.Reset = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"frequencies":     func(h *kvirtv1.FeatureHyperv) { h.Frequencies = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"reenlightenment": func(h *kvirtv1.FeatureHyperv) { h.Reenlightenment = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"tlbflush":        func(h *kvirtv1.FeatureHyperv) { h.TLBFlush = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"ipi":             func(h *kvirtv1.FeatureHyperv) { h.IPI = &kvirtv1.FeatureState{Enabled: enabled()} },
+	"evmcs":           func(h *kvirtv1.FeatureHyperv) { h.EVMCS = &kvirtv1.FeatureState{Enabled: enabled()} },
+}
+
+func enabled() *bool {
+	enabled := true
+	return &enabled
+}
+
 func baseVmi(name string) *kvirtv1.VirtualMachineInstance {
 	vmi := kvirtv1.NewVMIReferenceFromNameWithNS("", name)
 	vmi.Spec = kvirtv1.VirtualMachineInstanceSpec{Domain: kvirtv1.DomainSpec{}}
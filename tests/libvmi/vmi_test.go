@@ -0,0 +1,62 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package libvmi
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kvirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("VMI options", func() {
+
+	Context("with Hyper-V features", func() {
+		It("should enable the requested features only", func() {
+			vmi := New("testvmi", WithHyperVFeatures("relaxed", "spinlocks", "synictimer"))
+
+			hyperv := vmi.Spec.Domain.Features.Hyperv
+			Expect(*hyperv.Relaxed.Enabled).To(BeTrue())
+			Expect(*hyperv.Spinlocks.Enabled).To(BeTrue())
+			Expect(*hyperv.SyNICTimer.Enabled).To(BeTrue())
+			Expect(hyperv.VAPIC).To(BeNil())
+			Expect(hyperv.EVMCS).To(BeNil())
+		})
+
+		It("should keep the other VMI features", func() {
+			vmi := New("testvmi")
+			vmi.Spec.Domain.Features = &kvirtv1.Features{SMM: &kvirtv1.FeatureState{}}
+			WithHyperVFeatures("vapic")(vmi)
+
+			Expect(vmi.Spec.Domain.Features.SMM).ToNot(BeNil())
+			Expect(vmi.Spec.Domain.Features.Hyperv.VAPIC).ToNot(BeNil())
+		})
+
+		It("should reject unknown features", func() {
+			Expect(func() { New("testvmi", WithHyperVFeatures("relaxed", "turbo")) }).To(Panic())
+		})
+	})
+
+	It("should set the eviction strategy", func() {
+		vmi := New("testvmi", WithEvictionStrategy(kvirtv1.EvictionStrategyLiveMigrate))
+		Expect(vmi.Spec.EvictionStrategy).ToNot(BeNil())
+		Expect(*vmi.Spec.EvictionStrategy).To(Equal(kvirtv1.EvictionStrategyLiveMigrate))
+	})
+})
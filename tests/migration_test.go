@@ -725,9 +725,7 @@ var _ = Describe("[Serial][rfe_id:393][crit:high][vendor:cnv-qe@redhat.com][leve
 				tests.ConfirmVMIPostMigration(virtClient, vmi, migrationUID)
 
 				// ensure the libvirt domain is persistent
-				persistent, err := tests.LibvirtDomainIsPersistent(virtClient, vmi)
-				Expect(err).ToNot(HaveOccurred(), "Should list libvirt domains successfully")
-				Expect(persistent).To(BeTrue(), "The VMI was not found in the list of libvirt persistent domains")
+				tests.WaitForDomainPersistent(virtClient, vmi, 30)
 
 				// delete VMI
 				By("Deleting the VMI")
@@ -412,6 +412,13 @@ var _ = SIGDescribe("[Serial]Multus", func() {
 				table.Entry("[test_id:1578]with default network and secondary network", []v1.Interface{defaultInterface, linuxBridgeInterface}, []v1.Network{defaultNetwork, linuxBridgeNetwork}, "eth1", "10.1.1.1/24", "10.1.1.2/24"),
 				table.Entry("with default network and secondary network with IPAM", []v1.Interface{defaultInterface, linuxBridgeInterfaceWithIPAM}, []v1.Network{defaultNetwork, linuxBridgeWithIPAMNetwork}, "eth1", "", ""),
 			)
+
+			It("should set up a dummy NIC for the secondary bridge interface", func() {
+				vmi := createVMIOnNode([]v1.Interface{defaultInterface, linuxBridgeInterface}, []v1.Network{defaultNetwork, linuxBridgeNetwork})
+				tests.WaitUntilVMIReady(vmi, console.LoginToAlpine)
+
+				tests.VerifyDummyNic(vmi, "net1")
+			})
 		})
 
 		Context("VirtualMachineInstance with Linux bridge CNI plugin interface and custom MAC address.", func() {
@@ -295,6 +295,10 @@ var _ = SIGDescribe("[Serial][rfe_id:694][crit:medium][vendor:cnv-qe@redhat.com]
 			table.Entry("[test_id:1546]on a different node from Node", v12.NodeSelectorOpNotIn, true),
 		)
 
+		It("should ping the inbound VMI from the outbound VMI", func() {
+			Expect(tests.ExpectPingBetweenVMIs(outboundVMI, inboundVMI.Status.Interfaces[0].IP, libnet.WithIPv6(console.LoginToCirros))).To(Succeed())
+		})
+
 		Context("VirtualMachineInstance with default interface model", func() {
 			// Unless an explicit interface model is specified, the default interface model is virtio.
 			It("[test_id:1550]should expose the right device type to the guest", func() {
@@ -350,6 +354,15 @@ var _ = SIGDescribe("[Serial][rfe_id:694][crit:medium][vendor:cnv-qe@redhat.com]
 			tests.WaitUntilVMIReady(deadbeafVMI, console.LoginToAlpine)
 			checkMacAddress(deadbeafVMI, deadbeafVMI.Spec.Domain.Devices.Interfaces[0].MacAddress)
 		})
+
+		It("should expose the custom MAC address in the guest sysfs", func() {
+			deadbeafVMI := tests.NewRandomVMIWithCustomMacAddress()
+			_, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(deadbeafVMI)
+			Expect(err).ToNot(HaveOccurred())
+
+			tests.WaitUntilVMIReady(deadbeafVMI, console.LoginToAlpine)
+			Expect(tests.ExpectGuestMAC(deadbeafVMI, console.LoginToAlpine, "eth0", deadbeafVMI.Spec.Domain.Devices.Interfaces[0].MacAddress)).To(Succeed())
+		})
 	})
 
 	Context("VirtualMachineInstance with custom MAC address in non-conventional format", func() {
@@ -743,6 +756,30 @@ var _ = SIGDescribe("[Serial][rfe_id:694][crit:medium][vendor:cnv-qe@redhat.com]
 				table.Entry("with custom CIDR [IPv4]", []v1.Port{}, 8080, "10.10.10.0/24"),
 			)
 
+			It("should resolve the kubernetes service from the guest [IPv4]", func() {
+				kubernetesServiceIP, err := tests.GetKubernetesApiServiceIp(virtClient)
+				Expect(err).ToNot(HaveOccurred())
+
+				vmi := masqueradeVMI([]v1.Port{}, "")
+				_, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				vmi = tests.WaitUntilVMIReady(vmi, console.LoginToCirros)
+
+				Expect(tests.ExpectDNSResolution(vmi, console.LoginToCirros, "kubernetes.default.svc.cluster.local", kubernetesServiceIP)).To(Succeed())
+			})
+
+			It("should use the gateway of the custom CIDR as the guest default route [IPv4]", func() {
+				const networkCIDR = "10.10.10.0/24"
+				vmi := masqueradeVMI([]v1.Port{}, networkCIDR)
+				_, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				vmi = tests.WaitUntilVMIReady(vmi, console.LoginToCirros)
+
+				gateway, err := tests.GetGuestDefaultRouteIP(vmi, console.LoginToCirros)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(gateway).To(Equal(gatewayIPFromCIDR(networkCIDR)))
+			})
+
 			It("[outside_connectivity]should be able to reach the outside world [IPv4]", func() {
 				ipv4Address := "8.8.8.8"
 				if flags.IPV4ConnectivityCheckAddress != "" {
@@ -148,6 +148,10 @@ var _ = SIGDescribe("[Serial]Slirp Networking", func() {
 		table.Entry("VirtualMachineInstance with slirp interface with custom MAC address", &deadbeafVmi),
 	)
 
+	It("should forward the exposed port into the virt-launcher pod", func() {
+		Expect(tests.ExpectSlirpPortForwarded(genericVmi, 80)).To(Succeed())
+	})
+
 	table.DescribeTable("[outside_connectivity]should be able to communicate with the outside world", func(vmiRef **v1.VirtualMachineInstance) {
 		vmi := *vmiRef
 		dns := "google.com"
@@ -79,6 +79,24 @@ var _ = Describe("[rfe_id:3064][crit:medium][vendor:cnv-qe@redhat.com][level:com
 			})
 		})
 
+		When("paused via the test helpers", func() {
+			It("should pause and unpause the libvirt domain", func() {
+				runVMI()
+
+				tests.PauseVMIAndWait(vmi, 30)
+				tests.UnpauseVMIAndWait(vmi, 30)
+			})
+
+			It("should start a VMI with the paused start strategy in paused state", func() {
+				vmi = tests.NewRandomVMIWithStartStrategyPaused()
+				vmi, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				tests.ExpectVMIStartedPaused(vmi, 90)
+				tests.UnpauseVMIAndWait(vmi, 30)
+			})
+		})
+
 		When("paused via virtctl", func() {
 			It("[test_id:3079]should signal paused state with condition", func() {
 				runVMI()
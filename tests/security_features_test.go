@@ -247,6 +247,10 @@ var _ = Describe("[Serial][sig-compute]SecurityFeatures", func() {
 				Expect(caps.Add).To(ContainElements(k8sv1.Capability("NET_BIND_SERVICE"), k8sv1.Capability("SYS_NICE")))
 			}
 
+			By("Checking virsh connects to the libvirt instance of the VMI")
+			Expect(tests.IsVMINonRoot(vmi)).To(Equal(checks.HasFeature(virtconfig.NonRoot)))
+			Expect(tests.ExpectVirshReachable(vmi)).To(Succeed())
+
 			By("Checking virt-launcher Pod's compute container has precisely the documented extra capabilities")
 			for _, cap := range caps.Add {
 				Expect(tests.IsLauncherCapabilityValid(cap)).To(BeTrue(), "Expected compute container of virt_launcher to be granted only specific capabilities")
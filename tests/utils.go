@@ -47,6 +47,7 @@ import (
 	"sync"
 	"time"
 
+	promv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	expect "github.com/google/goexpect"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/config"
@@ -54,8 +55,10 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh"
 	k8sv1 "k8s.io/api/core/v1"
+	networkv1 "k8s.io/api/networking/v1"
 	nodev1 "k8s.io/api/node/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	extclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -69,6 +72,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -84,6 +88,7 @@ import (
 	util2 "kubevirt.io/kubevirt/tests/util"
 
 	"kubevirt.io/kubevirt/tests/framework/cleanup"
+	"kubevirt.io/kubevirt/tests/framework/parse"
 
 	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
@@ -95,6 +100,7 @@ import (
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/hooks"
 	kutil "kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/cluster"
 	"kubevirt.io/kubevirt/pkg/util/net/ip"
@@ -142,6 +148,14 @@ const (
 
 const defaultTestGracePeriod int64 = 0
 
+// nonRootLibvirtURI is the libvirt connection URI of VMIs running as non-root
+const nonRootLibvirtURI = "qemu+unix:///session?socket=/var/run/libvirt/libvirt-sock"
+
+// evictionInProgressAnnotation is the annotation the descheduler looks for on pods whose eviction is handled
+// in the background, e.g. by a live migration. Nothing in KubeVirt sets it yet, an evicted VMI is only
+// marked through its evacuation node name.
+const evictionInProgressAnnotation = "descheduler.alpha.kubernetes.io/eviction-in-progress"
+
 const (
 	SubresourceServiceAccountName = "kubevirt-subresource-test-sa"
 	AdminServiceAccountName       = "kubevirt-admin-test-sa"
@@ -922,6 +936,16 @@ func GetSupportedCPUModels(nodes k8sv1.NodeList) []string {
 	return cpus
 }
 
+// ForEachSupportedCPUModel calls fn for every CPU model supported by the nodes, in alphabetical order,
+// skipping the deny-listed models
+func ForEachSupportedCPUModel(nodes k8sv1.NodeList, fn func(model string)) {
+	models := GetSupportedCPUModels(nodes)
+	sort.Strings(models)
+	for _, model := range models {
+		fn(model)
+	}
+}
+
 func CreateConfigMap(name string, data map[string]string) {
 	virtCli, err := kubecli.GetKubevirtClient()
 	util2.PanicOnError(err)
@@ -962,6 +986,156 @@ func CreatePVC(os, size, storageClass string, recycledPV bool) {
 	}
 }
 
+func CreateResourceQuota(namespace string, hard k8sv1.ResourceList) *k8sv1.ResourceQuota {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	resourceQuota, err := virtCli.CoreV1().ResourceQuotas(namespace).Create(
+		context.Background(),
+		&k8sv1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-quota-" + rand.String(5)},
+			Spec: k8sv1.ResourceQuotaSpec{
+				Hard: hard,
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	return resourceQuota
+}
+
+func CreateLimitRange(namespace string, limits []k8sv1.LimitRangeItem) *k8sv1.LimitRange {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	limitRange, err := virtCli.CoreV1().LimitRanges(namespace).Create(
+		context.Background(),
+		&k8sv1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-limits-" + rand.String(5)},
+			Spec: k8sv1.LimitRangeSpec{
+				Limits: limits,
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	return limitRange
+}
+
+// ExpectVMIDefaultsFromLimitRange verifies that every container of the VMI's launcher pod
+// got a request for each resource defaulted by the limit ranges of the VMI's namespace.
+// Containers which don't request the resource on their own must request the default value.
+func ExpectVMIDefaultsFromLimitRange(vmi *v1.VirtualMachineInstance) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	limitRanges, err := virtCli.CoreV1().LimitRanges(vmi.Namespace).List(context.Background(), metav1.ListOptions{})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, limitRanges.Items).ToNot(BeEmpty(), "no limit range found in namespace %s", vmi.Namespace)
+
+	pods, err := virtCli.CoreV1().Pods(vmi.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", v1.CreatedByLabel, string(vmi.GetUID())),
+	})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, pods.Items).To(HaveLen(1), "expected exactly one launcher pod for VMI %s", vmi.Name)
+
+	for _, limitRange := range limitRanges.Items {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != k8sv1.LimitTypeContainer {
+				continue
+			}
+			for resourceName, defaultRequest := range item.DefaultRequest {
+				for _, container := range pods.Items[0].Spec.Containers {
+					request, exists := container.Resources.Requests[resourceName]
+					ExpectWithOffset(1, exists).To(BeTrue(),
+						"container %s of VMI %s should have a %s request", container.Name, vmi.Name, resourceName)
+					if requestRenderedByVirtController(container, resourceName) {
+						continue
+					}
+					ExpectWithOffset(1, request.Cmp(defaultRequest)).To(BeZero(),
+						"container %s of VMI %s should request the default %s %s, got %s", container.Name, vmi.Name, resourceName, defaultRequest.String(), request.String())
+				}
+			}
+		}
+	}
+}
+
+// requestRenderedByVirtController tells whether virt-controller sets the request of the resource on the
+// launcher pod container itself, in which case a LimitRange default request doesn't apply to it.
+// The compute container and the containers serving container disks always request cpu, memory and ephemeral storage.
+func requestRenderedByVirtController(container k8sv1.Container, resourceName k8sv1.ResourceName) bool {
+	if container.Name != "compute" && !strings.HasPrefix(container.Name, "volume") {
+		return false
+	}
+	switch resourceName {
+	case k8sv1.ResourceCPU, k8sv1.ResourceMemory, k8sv1.ResourceEphemeralStorage:
+		return true
+	}
+	return false
+}
+
+// ApplyNetworkPolicy creates a network policy with the given spec and returns it together with
+// a function deleting it and waiting for its removal
+func ApplyNetworkPolicy(namespace string, spec networkv1.NetworkPolicySpec) (*networkv1.NetworkPolicy, func()) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	policy, err := virtCli.NetworkingV1().NetworkPolicies(namespace).Create(
+		context.Background(),
+		&networkv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-policy-" + rand.String(5)},
+			Spec:       spec,
+		},
+		metav1.CreateOptions{},
+	)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+	cleanup := func() {
+		err := virtCli.NetworkingV1().NetworkPolicies(namespace).Delete(context.Background(), policy.Name, metav1.DeleteOptions{})
+		if !errors.IsNotFound(err) {
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		}
+		EventuallyWithOffset(1, func() error {
+			_, err := virtCli.NetworkingV1().NetworkPolicies(namespace).Get(context.Background(), policy.Name, metav1.GetOptions{})
+			return err
+		}, 10*time.Second, time.Second).Should(SatisfyAll(HaveOccurred(), WithTransform(errors.IsNotFound, BeTrue())))
+	}
+	return policy, cleanup
+}
+
+// ExpectVMICreationRejected tries to create the VMI and verifies that the API server
+// refused it with an error containing reasonSubstring
+func ExpectVMICreationRejected(vmi *v1.VirtualMachineInstance, reasonSubstring string) {
+	expectVMICreationRejected(2, vmi, reasonSubstring)
+}
+
+// ExpectVMICreationFails tries to create the VMI and verifies that admission failed
+// with an error containing reasonSubstring, see ExpectVMICreationRejected
+func ExpectVMICreationFails(vmi *v1.VirtualMachineInstance, reasonSubstring string) {
+	expectVMICreationRejected(2, vmi, reasonSubstring)
+}
+
+func expectVMICreationRejected(offset int, vmi *v1.VirtualMachineInstance, reasonSubstring string) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	_, err = virtCli.VirtualMachineInstance(vmi.Namespace).Create(vmi)
+	ExpectWithOffset(offset, err).To(MatchError(ContainSubstring(reasonSubstring)), fmt.Sprintf("creation of VMI %s should be rejected", vmi.Name))
+}
+
+// ExpectVMIDefaulted creates the VMI, fetches it back and runs check against the object
+// as it was defaulted by the mutating webhook
+func ExpectVMIDefaulted(vmi *v1.VirtualMachineInstance, check func(*v1.VirtualMachineInstance) error) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	_, err = virtCli.VirtualMachineInstance(vmi.Namespace).Create(vmi)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	defaulted, err := virtCli.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &metav1.GetOptions{})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, check(defaulted)).To(Succeed(), "VMI %s should be defaulted", vmi.Name)
+}
+
 func CreateRuntimeClass(name, handler string) (*nodev1.RuntimeClass, error) {
 	virtCli, err := kubecli.GetKubevirtClient()
 	if err != nil {
@@ -987,6 +1161,31 @@ func DeleteRuntimeClass(name string) error {
 	return virtCli.NodeV1beta1().RuntimeClasses().Delete(context.Background(), name, metav1.DeleteOptions{})
 }
 
+func CreatePriorityClass(name string, value int32) (*schedulingv1.PriorityClass, error) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return virtCli.SchedulingV1().PriorityClasses().Create(
+		context.Background(),
+		&schedulingv1.PriorityClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Value:      value,
+		},
+		metav1.CreateOptions{},
+	)
+}
+
+func DeletePriorityClass(name string) error {
+	virtCli, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return err
+	}
+
+	return virtCli.SchedulingV1().PriorityClasses().Delete(context.Background(), name, metav1.DeleteOptions{})
+}
+
 func newPVC(os, size, storageClass string, recycledPV bool) *k8sv1.PersistentVolumeClaim {
 	quantity, err := resource.ParseQuantity(size)
 	util2.PanicOnError(err)
@@ -1176,6 +1375,75 @@ func PrometheusRuleEnabled() bool {
 	return prometheusRuleEnabled
 }
 
+// GetPrometheusRules returns the PrometheusRules in the KubeVirt install namespace.
+// It fails if the PrometheusRule CRD is not installed.
+func GetPrometheusRules() ([]promv1.PrometheusRule, error) {
+	if !PrometheusRuleEnabled() {
+		return nil, fmt.Errorf("the PrometheusRule CRD is not installed")
+	}
+	virtClient, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return nil, err
+	}
+	ruleList, err := virtClient.PrometheusClient().MonitoringV1().PrometheusRules(flags.KubeVirtInstallNamespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]promv1.PrometheusRule, 0, len(ruleList.Items))
+	for _, rule := range ruleList.Items {
+		rules = append(rules, *rule)
+	}
+	return rules, nil
+}
+
+// ExpectAlertDefined verifies that one of the KubeVirt PrometheusRules defines the alert
+func ExpectAlertDefined(name string) {
+	rules, err := GetPrometheusRules()
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, parse.AlertDefined(rules, name)).To(BeTrue(), "alert %s should be defined", name)
+}
+
+// prometheusServices are the namespaced names of the Prometheus services deployed by the
+// OpenShift cluster monitoring and by kube-prometheus
+var prometheusServices = []types.NamespacedName{
+	{Namespace: "openshift-monitoring", Name: "prometheus-k8s"},
+	{Namespace: "monitoring", Name: "prometheus-k8s"},
+}
+
+// WaitForAlertActive waits until the in-cluster Prometheus reports the alert as active.
+// The test is skipped if Prometheus is not deployed or not reachable.
+func WaitForAlertActive(alertName string, timeoutSec int) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	var queryAlerts func() ([]byte, error)
+	for _, svc := range prometheusServices {
+		_, err := virtClient.CoreV1().Services(svc.Namespace).Get(context.Background(), svc.Name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		svc := svc
+		queryAlerts = func() ([]byte, error) {
+			return virtClient.CoreV1().Services(svc.Namespace).ProxyGet("", svc.Name, "web", "/api/v1/alerts", nil).DoRaw(context.Background())
+		}
+		break
+	}
+	if queryAlerts == nil {
+		Skip("Prometheus is not deployed")
+	}
+	if _, err := queryAlerts(); err != nil {
+		Skip(fmt.Sprintf("Prometheus is not reachable: %v", err))
+	}
+
+	EventuallyWithOffset(1, func() (bool, error) {
+		body, err := queryAlerts()
+		if err != nil {
+			return false, err
+		}
+		return parse.AlertActive(body, alertName)
+	}, time.Duration(timeoutSec)*time.Second, 5*time.Second).Should(BeTrue(), "alert %s should be active", alertName)
+}
+
 func composeResourceURI(object unstructured.Unstructured) string {
 	uri := "/api"
 	if object.GetAPIVersion() != "v1" {
@@ -1453,13 +1721,17 @@ func DeletePV(os string) {
 }
 
 func VerifyDummyNicForBridgeNetwork(vmi *v1.VirtualMachineInstance) {
-	output := RunCommandOnVmiPod(vmi, []string{"/bin/bash", "-c", "/usr/sbin/ip link show|grep DOWN|grep -c eth0"})
-	ExpectWithOffset(1, strings.TrimSpace(output)).To(Equal("1"))
+	VerifyDummyNic(vmi, "eth0")
+}
 
-	output = RunCommandOnVmiPod(vmi, []string{"/bin/bash", "-c", "/usr/sbin/ip link show|grep UP|grep -c eth0-nic"})
+// VerifyDummyNic verifies that the original pod interface ifaceName is DOWN and
+// that its renamed counterpart <ifaceName>-nic is UP
+func VerifyDummyNic(vmi *v1.VirtualMachineInstance, ifaceName string) {
+	output := RunCommandOnVmiPod(vmi, []string{"/bin/bash", "-c", fmt.Sprintf("/usr/sbin/ip link show|grep DOWN|grep -c %s", ifaceName)})
 	ExpectWithOffset(1, strings.TrimSpace(output)).To(Equal("1"))
 
-	return
+	output = RunCommandOnVmiPod(vmi, []string{"/bin/bash", "-c", fmt.Sprintf("/usr/sbin/ip link show|grep UP|grep -c %s-nic", ifaceName)})
+	ExpectWithOffset(1, strings.TrimSpace(output)).To(Equal("1"))
 }
 
 func RunVMI(vmi *v1.VirtualMachineInstance, timeout int) *v1.VirtualMachineInstance {
@@ -1625,6 +1897,12 @@ func GetComputeContainerOfPod(pod *k8sv1.Pod) *k8sv1.Container {
 	return GetContainerOfPod(pod, "compute")
 }
 
+// GetComputeContainerProbes returns the readiness and liveness probes of the compute container of the launcher pod
+func GetComputeContainerProbes(pod *k8sv1.Pod) (readiness, liveness *k8sv1.Probe) {
+	computeContainer := GetComputeContainerOfPod(pod)
+	return computeContainer.ReadinessProbe, computeContainer.LivenessProbe
+}
+
 func GetContainerDiskContainerOfPod(pod *k8sv1.Pod, volumeName string) *k8sv1.Container {
 	diskContainerName := fmt.Sprintf("volume%s", volumeName)
 	return GetContainerOfPod(pod, diskContainerName)
@@ -1732,6 +2010,8 @@ func cleanNamespaces() {
 		util2.PanicOnError(virtCli.RestClient().Delete().Namespace(namespace).Resource("virtualmachineinstancepresets").Do(context.Background()).Error())
 		// Remove all limit ranges
 		util2.PanicOnError(virtCli.CoreV1().RESTClient().Delete().Namespace(namespace).Resource("limitranges").Do(context.Background()).Error())
+		// Remove all resource quotas
+		util2.PanicOnError(virtCli.CoreV1().RESTClient().Delete().Namespace(namespace).Resource("resourcequotas").Do(context.Background()).Error())
 
 		// Remove all Migration Objects
 		util2.PanicOnError(virtCli.RestClient().Delete().Namespace(namespace).Resource("virtualmachineinstancemigrations").Do(context.Background()).Error())
@@ -2251,13 +2531,19 @@ func AddBootOrderToDisk(vmi *v1.VirtualMachineInstance, diskName string, bootord
 }
 
 func AddPVCDisk(vmi *v1.VirtualMachineInstance, name string, bus string, claimName string) *v1.VirtualMachineInstance {
+	return AddPVCDiskWithOptions(vmi, name, claimName, bus, false, "")
+}
+
+func AddPVCDiskWithOptions(vmi *v1.VirtualMachineInstance, name, claimName string, bus string, readonly bool, serial string) *v1.VirtualMachineInstance {
 	vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
 		Name: name,
 		DiskDevice: v1.DiskDevice{
 			Disk: &v1.DiskTarget{
-				Bus: bus,
+				Bus:      bus,
+				ReadOnly: readonly,
 			},
 		},
+		Serial: serial,
 	})
 	vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
 		Name: name,
@@ -2326,6 +2612,22 @@ func NewRandomFedoraVMIWithBlacklistGuestAgent(commands string) *v1.VirtualMachi
 	)
 }
 
+// NewRandomVMIWithStaticIP creates a Fedora VMI whose eth0 is statically configured through
+// cloud-init network data. ip has to be given in CIDR notation.
+func NewRandomVMIWithStaticIP(ip, gateway string) *v1.VirtualMachineInstance {
+	networkData, err := libnet.NewNetworkData(
+		libnet.WithEthernet("eth0",
+			libnet.WithAddresses(ip),
+			libnet.WithGateway4(gateway),
+		),
+	)
+	Expect(err).NotTo(HaveOccurred())
+
+	vmi := NewRandomVMIWithEphemeralDisk(cd.ContainerDiskFor(cd.ContainerDiskFedoraTestTooling))
+	AddCloudInitNoCloudData(vmi, "disk1", "#!/bin/bash\necho 'hello'\n", networkData, false)
+	return vmi
+}
+
 func AddPVCFS(vmi *v1.VirtualMachineInstance, name string, claimName string) *v1.VirtualMachineInstance {
 	vmi.Spec.Domain.Devices.Filesystems = append(vmi.Spec.Domain.Devices.Filesystems, v1.Filesystem{
 		Name:     name,
@@ -2699,6 +3001,205 @@ func NewRandomVMIWithWatchdog() *v1.VirtualMachineInstance {
 	return vmi
 }
 
+// NewRandomVMIWithRNG returns an alpine VMI with a virtio RNG device, which feeds the guest entropy from the host
+func NewRandomVMIWithRNG() *v1.VirtualMachineInstance {
+	vmi := NewRandomVMIWithEphemeralDisk(cd.ContainerDiskFor(cd.ContainerDiskAlpine))
+	vmi.Spec.Domain.Devices.Rng = &v1.Rng{}
+	return vmi
+}
+
+// WithTabletDevice appends a tablet input device on the given bus (usb or virtio) to the VMI
+func WithTabletDevice(vmi *v1.VirtualMachineInstance, bus string) {
+	vmi.Spec.Domain.Devices.Inputs = append(vmi.Spec.Domain.Devices.Inputs, v1.Input{
+		Name: fmt.Sprintf("tablet%d", len(vmi.Spec.Domain.Devices.Inputs)),
+		Bus:  bus,
+		Type: "tablet",
+	})
+}
+
+// WithSSHAccessCredential adds an access credential which propagates the public ssh keys of the secret
+// to the authorized keys of user through the guest agent
+func WithSSHAccessCredential(vmi *v1.VirtualMachineInstance, secretName, user string) {
+	vmi.Spec.AccessCredentials = append(vmi.Spec.AccessCredentials, v1.AccessCredential{
+		SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+			Source: v1.SSHPublicKeyAccessCredentialSource{
+				Secret: &v1.AccessCredentialSecretSource{SecretName: secretName},
+			},
+			PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+				QemuGuestAgent: &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{
+					Users: []string{user},
+				},
+			},
+		},
+	})
+}
+
+// WithPasswordAccessCredential adds an access credential which sets the guest user passwords from the secret
+// through the guest agent
+func WithPasswordAccessCredential(vmi *v1.VirtualMachineInstance, secretName string) {
+	vmi.Spec.AccessCredentials = append(vmi.Spec.AccessCredentials, v1.AccessCredential{
+		UserPassword: &v1.UserPasswordAccessCredential{
+			Source: v1.UserPasswordAccessCredentialSource{
+				Secret: &v1.AccessCredentialSecretSource{SecretName: secretName},
+			},
+			PropagationMethod: v1.UserPasswordAccessCredentialPropagationMethod{
+				QemuGuestAgent: &v1.QemuGuestAgentUserPasswordAccessCredentialPropagation{},
+			},
+		},
+	})
+}
+
+// NewRandomVMIWithStartStrategyPaused returns a cirros VMI which is started in paused state
+func NewRandomVMIWithStartStrategyPaused() *v1.VirtualMachineInstance {
+	vmi := NewRandomVMIWithEphemeralDisk(cd.ContainerDiskFor(cd.ContainerDiskCirros))
+	strategy := v1.StartStrategyPaused
+	vmi.Spec.StartStrategy = &strategy
+	return vmi
+}
+
+// NewRandomVMIWithCPUModel returns a cirros VMI requesting the given CPU model
+func NewRandomVMIWithCPUModel(model string) *v1.VirtualMachineInstance {
+	vmi := NewRandomVMIWithEphemeralDiskAndUserdata(cd.ContainerDiskFor(cd.ContainerDiskCirros), "#!/bin/bash\necho 'hello'\n")
+	if vmi.Spec.Domain.CPU == nil {
+		vmi.Spec.Domain.CPU = &v1.CPU{}
+	}
+	vmi.Spec.Domain.CPU.Model = model
+	return vmi
+}
+
+// ExpectGuestCPUModel verifies that the CPU model name reported by the guest contains expectedSubstring
+func ExpectGuestCPUModel(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, expectedSubstring string) error {
+	output, err := runCommandOnGuest(vmi, loginTo, "grep -m1 'model name' /proc/cpuinfo", 15)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(output, expectedSubstring) {
+		return fmt.Errorf("expected the guest CPU model to contain %s, got: %s", expectedSubstring, strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// AddSidecarHook appends the hook sidecar to the ones requested by the VMI annotations
+func AddSidecarHook(vmi *v1.VirtualMachineInstance, sidecar hooks.HookSidecar) {
+	sidecars, err := hooks.UnmarshalHookSidecarList(vmi)
+	util2.PanicOnError(err)
+	sidecars = append(sidecars, sidecar)
+	rawSidecars, err := json.Marshal(sidecars)
+	util2.PanicOnError(err)
+	if vmi.Annotations == nil {
+		vmi.Annotations = map[string]string{}
+	}
+	vmi.Annotations[hooks.HookSidecarListAnnotationName] = string(rawSidecars)
+}
+
+// AddDebugSidecar adds a hook sidecar running the given image to the VMI, so that it can be used for debugging.
+// Like every container of the launcher pod the sidecar shares the network and IPC namespaces with the compute container.
+// No annotation asks for a shared PID namespace, virt-controller never sets ShareProcessNamespace on launcher pods.
+func AddDebugSidecar(vmi *v1.VirtualMachineInstance, image string) {
+	AddSidecarHook(vmi, hooks.HookSidecar{
+		Image:           image,
+		ImagePullPolicy: k8sv1.PullIfNotPresent,
+	})
+}
+
+// NewRandomVMIWithHugepages returns a cirros VMI whose memory is backed by hugepages of the given size
+func NewRandomVMIWithHugepages(pageSize, memory string) *v1.VirtualMachineInstance {
+	vmi := NewRandomVMIWithEphemeralDiskAndUserdata(cd.ContainerDiskFor(cd.ContainerDiskCirros), "#!/bin/bash\necho 'hello'\n")
+	vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory] = resource.MustParse(memory)
+	vmi.Spec.Domain.Memory = &v1.Memory{
+		Hugepages: &v1.Hugepages{PageSize: pageSize},
+	}
+	return vmi
+}
+
+// ExpectGuestHugepages verifies the hugepage size in kB reported by /proc/meminfo in the guest
+func ExpectGuestHugepages(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, pageSizeKB int) error {
+	output, err := runCommandOnGuest(vmi, loginTo, "grep Hugepagesize /proc/meminfo", 15)
+	if err != nil {
+		return err
+	}
+	actualKB, err := parse.HugepageSizeKB(output)
+	if err != nil {
+		return err
+	}
+	if actualKB != pageSizeKB {
+		return fmt.Errorf("expected a hugepage size of %d kB in the guest, got %d kB", pageSizeKB, actualKB)
+	}
+	return nil
+}
+
+// NewRandomVMIWithHostPassthroughCPU returns a cirros VMI which gets the same CPU as the node it runs on
+func NewRandomVMIWithHostPassthroughCPU() *v1.VirtualMachineInstance {
+	return NewRandomVMIWithCPUModel(v1.CPUModeHostPassthrough)
+}
+
+// ExpectGuestCPUFeature verifies that the guest CPU flags include feature
+func ExpectGuestCPUFeature(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, feature string) error {
+	output, err := runCommandOnGuest(vmi, loginTo, "grep -m1 '^flags' /proc/cpuinfo", 15)
+	if err != nil {
+		return err
+	}
+	for _, flag := range strings.Fields(output) {
+		if flag == feature {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected the guest CPU flags to include %s, got: %s", feature, strings.TrimSpace(output))
+}
+
+// ExpectEvictionStrategy verifies that the VMI has the expected eviction strategy set
+func ExpectEvictionStrategy(vmi *v1.VirtualMachineInstance, expected v1.EvictionStrategy) {
+	ExpectWithOffset(1, vmi.Spec.EvictionStrategy).ToNot(BeNil(), "VMI %s should have an eviction strategy", vmi.Name)
+	if vmi.Spec.EvictionStrategy != nil {
+		ExpectWithOffset(1, *vmi.Spec.EvictionStrategy).To(Equal(expected), "VMI %s should have eviction strategy %s", vmi.Name, expected)
+	}
+}
+
+// WithTCPReadinessProbe sets a readiness probe on the VMI which connects to the guest on the given TCP port
+func WithTCPReadinessProbe(vmi *v1.VirtualMachineInstance, port int32) {
+	vmi.Spec.ReadinessProbe = &v1.Probe{
+		Handler: v1.Handler{
+			TCPSocket: &k8sv1.TCPSocketAction{Port: intstr.FromInt(int(port))},
+		},
+	}
+}
+
+// WithHTTPLivenessProbe sets a liveness probe on the VMI which sends an HTTP GET for path to the given guest port
+func WithHTTPLivenessProbe(vmi *v1.VirtualMachineInstance, path string, port int32) {
+	vmi.Spec.LivenessProbe = &v1.Probe{
+		Handler: v1.Handler{
+			HTTPGet: &k8sv1.HTTPGetAction{Path: path, Port: intstr.FromInt(int(port))},
+		},
+	}
+}
+
+// NewRandomVMIWithMachineType returns a VMI which requests the given machine type, e.g. q35
+func NewRandomVMIWithMachineType(machineType string) *v1.VirtualMachineInstance {
+	vmi := NewRandomVMI()
+	vmi.Spec.Domain.Machine = &v1.Machine{Type: machineType}
+	return vmi
+}
+
+// NewRandomVMIWithHostname returns a cirros VMI with the given hostname and subdomain, which cloud-init applies in the guest
+func NewRandomVMIWithHostname(hostname, subdomain string) *v1.VirtualMachineInstance {
+	vmi := NewRandomVMIWithEphemeralDiskAndUserdata(cd.ContainerDiskFor(cd.ContainerDiskCirros), "#!/bin/sh\n\necho 'hello'\n")
+	vmi.Spec.Hostname = hostname
+	vmi.Spec.Subdomain = subdomain
+	return vmi
+}
+
+// ExpectGuestHostname verifies that the hostname reported by the guest matches expected
+func ExpectGuestHostname(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, expected string) error {
+	output, err := runCommandOnGuest(vmi, loginTo, "hostname", 15)
+	if err != nil {
+		return err
+	}
+	if hostname := strings.TrimSpace(output); hostname != expected {
+		return fmt.Errorf("expected guest hostname %s, got %s", expected, hostname)
+	}
+	return nil
+}
+
 func NewRandomVMIWithConfigMap(configMapName string) *v1.VirtualMachineInstance {
 	vmi := NewRandomVMIWithPVC(DiskAlpineHostPath)
 	AddConfigMapDisk(vmi, configMapName, configMapName)
@@ -2992,6 +3493,26 @@ func WaitForVirtualMachineToDisappearWithTimeout(vmi *v1.VirtualMachineInstance,
 	}, seconds, 1*time.Second).Should(SatisfyAll(HaveOccurred(), WithTransform(errors.IsNotFound, BeTrue())), "The VMI should be gone within the given timeout")
 }
 
+// DeleteVMIAndMeasureShutdown deletes the VMI and returns how long it took until the VMI was gone.
+// The VMI is expected to disappear within its termination grace period plus a minute.
+func DeleteVMIAndMeasureShutdown(vmi *v1.VirtualMachineInstance) (time.Duration, error) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return 0, err
+	}
+	gracePeriod := int64(v1.DefaultGracePeriodSeconds)
+	if vmi.Spec.TerminationGracePeriodSeconds != nil {
+		gracePeriod = *vmi.Spec.TerminationGracePeriodSeconds
+	}
+
+	start := time.Now()
+	if err := virtClient.VirtualMachineInstance(vmi.Namespace).Delete(vmi.Name, &metav1.DeleteOptions{}); err != nil {
+		return 0, err
+	}
+	WaitForVirtualMachineToDisappearWithTimeout(vmi, int(gracePeriod)+60)
+	return time.Since(start), nil
+}
+
 func WaitForMigrationToDisappearWithTimeout(migration *v1.VirtualMachineInstanceMigration, seconds int) {
 	virtClient, err := kubecli.GetKubevirtClient()
 	ExpectWithOffset(1, err).ToNot(HaveOccurred())
@@ -3073,12 +3594,280 @@ func LoginToVM(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory) *
 	return vmi
 }
 
-func NewInt32(x int32) *int32 {
-	return &x
+// runCommandOnGuest logs into the VMI and returns the output printed by the command
+func runCommandOnGuest(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, command string, timeout int) (string, error) {
+	if err := loginTo(vmi); err != nil {
+		return "", err
+	}
+
+	output, err := console.SafeExpectCommandOutput(vmi, command, timeout)
+	if err != nil {
+		return "", fmt.Errorf("failed to run [%s] on VMI %s: %v", command, vmi.Name, err)
+	}
+	return output, nil
 }
 
-func NewRandomReplicaSetFromVMI(vmi *v1.VirtualMachineInstance, replicas int32) *v1.VirtualMachineInstanceReplicaSet {
-	name := "replicaset" + rand.String(5)
+// GetGuestCPUCount returns the number of CPUs available in the guest as reported by nproc
+func GetGuestCPUCount(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory) (int, error) {
+	output, err := runCommandOnGuest(vmi, loginTo, "nproc", 15)
+	if err != nil {
+		return 0, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the nproc output %q: %v", output, err)
+	}
+	return count, nil
+}
+
+// ExpectGuestCPUTopology verifies the CPU topology reported by lscpu in the guest
+func ExpectGuestCPUTopology(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, sockets, cores, threads int) error {
+	output, err := runCommandOnGuest(vmi, loginTo, "lscpu", 15)
+	if err != nil {
+		return err
+	}
+	actualSockets, actualCores, actualThreads, err := parse.LSCPUTopology(output)
+	if err != nil {
+		return err
+	}
+	if actualSockets != sockets || actualCores != cores || actualThreads != threads {
+		return fmt.Errorf("expected %d sockets, %d cores and %d threads in the guest, got %d sockets, %d cores and %d threads",
+			sockets, cores, threads, actualSockets, actualCores, actualThreads)
+	}
+	return nil
+}
+
+// ExpectGuestMemoryApprox verifies that the total memory seen by the guest is
+// within tolerancePercent of expectedMiB
+func ExpectGuestMemoryApprox(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, expectedMiB int, tolerancePercent int) error {
+	output, err := runCommandOnGuest(vmi, loginTo, "grep MemTotal /proc/meminfo", 15)
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Fields(output)
+	if len(fields) < 2 || fields[0] != "MemTotal:" {
+		return fmt.Errorf("unexpected /proc/meminfo output: %q", output)
+	}
+	memTotalKiB, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return fmt.Errorf("failed to parse MemTotal from %q: %v", output, err)
+	}
+
+	actualMiB := memTotalKiB / 1024
+	diff := actualMiB - expectedMiB
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff*100 > expectedMiB*tolerancePercent {
+		return fmt.Errorf("guest memory %dMiB is not within %d%% of %dMiB", actualMiB, tolerancePercent, expectedMiB)
+	}
+	return nil
+}
+
+// ExpectPingBetweenVMIs logs into the from VMI and pings toIP, using ping -6 for IPv6 targets
+func ExpectPingBetweenVMIs(from *v1.VirtualMachineInstance, toIP string, loginTo console.LoginToFactory) error {
+	if err := loginTo(from); err != nil {
+		return fmt.Errorf("failed to login to VMI %s: %v", from.Name, err)
+	}
+	return libnet.PingFromVMConsole(from, toIP)
+}
+
+// ExpectGuestMAC verifies that the MAC address of iface inside the guest is expectedMAC
+func ExpectGuestMAC(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, iface, expectedMAC string) error {
+	output, err := runCommandOnGuest(vmi, loginTo, fmt.Sprintf("cat /sys/class/net/%s/address", iface), 15)
+	if err != nil {
+		return err
+	}
+
+	actualMAC := strings.TrimSpace(output)
+	if !strings.EqualFold(actualMAC, expectedMAC) {
+		return fmt.Errorf("guest interface %s has MAC address %q, expected %q", iface, actualMAC, expectedMAC)
+	}
+	return nil
+}
+
+// GetGuestDefaultRouteIP returns the gateway of the default route configured in the guest
+func GetGuestDefaultRouteIP(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory) (string, error) {
+	output, err := runCommandOnGuest(vmi, loginTo, "ip route", 15)
+	if err != nil {
+		return "", err
+	}
+	return parse.DefaultRouteGateway(output)
+}
+
+// ExpectDNSResolution runs nslookup for hostname in the guest and verifies that it resolves to expectedIP
+func ExpectDNSResolution(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, hostname, expectedIP string) error {
+	output, err := runCommandOnGuest(vmi, loginTo, "nslookup "+hostname, 30)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := parse.NSLookupAddresses(output)
+	if err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		if address == expectedIP {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s resolved to %v, expected %s", hostname, addresses, expectedIP)
+}
+
+// ExpectRNGInGuest verifies that the guest kernel registered the virtio rng device as a hardware random source
+func ExpectRNGInGuest(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory) error {
+	output, err := runCommandOnGuest(vmi, loginTo, "cat /sys/devices/virtual/misc/hw_random/rng_available", 30)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(output, "virtio") {
+		return fmt.Errorf("no virtio rng device available in the guest: %q", output)
+	}
+	return nil
+}
+
+// ExpectGuestDiskBlockSize verifies the logical and physical block sizes the guest sees for the block device
+func ExpectGuestDiskBlockSize(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, device string, logical, physical int) error {
+	command := fmt.Sprintf("cat /sys/block/%[1]s/queue/logical_block_size /sys/block/%[1]s/queue/physical_block_size", device)
+	output, err := runCommandOnGuest(vmi, loginTo, command, 15)
+	if err != nil {
+		return err
+	}
+
+	sizes := strings.Fields(output)
+	if len(sizes) != 2 {
+		return fmt.Errorf("unexpected block size output for %s: %q", device, output)
+	}
+	expected := []string{strconv.Itoa(logical), strconv.Itoa(physical)}
+	if sizes[0] != expected[0] || sizes[1] != expected[1] {
+		return fmt.Errorf("%s has logical/physical block sizes %s/%s, expected %s/%s", device, sizes[0], sizes[1], expected[0], expected[1])
+	}
+	return nil
+}
+
+// ExpectDownwardAPILabels mounts the downward API disk at mountpoint, unless it is already mounted there,
+// and verifies that its labels file contains the expected entries
+func ExpectDownwardAPILabels(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, mountpoint string, expected map[string]string) error {
+	volume := findVolume(vmi, func(source *v1.VolumeSource) bool {
+		return source.DownwardAPI != nil
+	})
+	if volume == nil {
+		return fmt.Errorf("VMI %s has no downward API volume", vmi.Name)
+	}
+	command := mountIsoDiskCommand(vmi, volume, mountpoint) + fmt.Sprintf("; cat %s/labels", mountpoint)
+	output, err := runCommandOnGuest(vmi, loginTo, command, 30)
+	if err != nil {
+		return err
+	}
+
+	labels := parse.DownwardAPILabels(output)
+	for key, value := range expected {
+		if actual, exists := labels[key]; !exists || actual != value {
+			return fmt.Errorf("expected label %s=%s in the downward API labels, got: %v", key, value, labels)
+		}
+	}
+	return nil
+}
+
+// findVolume returns the first volume of the VMI whose source matches, nil if there is none
+func findVolume(vmi *v1.VirtualMachineInstance, matches func(source *v1.VolumeSource) bool) *v1.Volume {
+	for i := range vmi.Spec.Volumes {
+		if matches(&vmi.Spec.Volumes[i].VolumeSource) {
+			return &vmi.Spec.Volumes[i]
+		}
+	}
+	return nil
+}
+
+// defaultIsoDiskLabel is the volume label of config map, secret and downward API disks without a custom label
+const defaultIsoDiskLabel = "cfgdata"
+
+// mountIsoDiskCommand returns a guest shell command which mounts the iso disk of the volume at mountpoint,
+// unless something is already mounted there. The disk is looked up by its serial if it has one
+// and by the volume label of the iso image otherwise.
+func mountIsoDiskCommand(vmi *v1.VirtualMachineInstance, volume *v1.Volume, mountpoint string) string {
+	device := ""
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Name == volume.Name && disk.Serial != "" {
+			device = fmt.Sprintf("$(ls /dev/disk/by-id/*%s | head -n 1)", disk.Serial)
+		}
+	}
+	if device == "" {
+		device = fmt.Sprintf("$(blkid -L %s)", isoDiskLabel(volume))
+	}
+	return fmt.Sprintf("mkdir -p %[1]s; grep -qs ' %[1]s ' /proc/mounts || mount -o ro %[2]s %[1]s", mountpoint, device)
+}
+
+// isoDiskLabel returns the volume label of the iso image of a config map, secret or downward API volume
+func isoDiskLabel(volume *v1.Volume) string {
+	label := ""
+	switch {
+	case volume.ConfigMap != nil:
+		label = volume.ConfigMap.VolumeLabel
+	case volume.Secret != nil:
+		label = volume.Secret.VolumeLabel
+	case volume.DownwardAPI != nil:
+		label = volume.DownwardAPI.VolumeLabel
+	}
+	if label == "" {
+		return defaultIsoDiskLabel
+	}
+	return label
+}
+
+// ExpectConfigMapInGuest mounts the config map disk at mountpoint and verifies the content of each expected key file
+func ExpectConfigMapInGuest(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, mountpoint string, expected map[string]string) error {
+	return expectIsoDiskFiles(vmi, loginTo, "config map", func(source *v1.VolumeSource) bool {
+		return source.ConfigMap != nil
+	}, mountpoint, expected)
+}
+
+// ExpectSecretInGuest mounts the secret disk at mountpoint and verifies the content of each expected key file
+func ExpectSecretInGuest(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, mountpoint string, expected map[string]string) error {
+	return expectIsoDiskFiles(vmi, loginTo, "secret", func(source *v1.VolumeSource) bool {
+		return source.Secret != nil
+	}, mountpoint, expected)
+}
+
+// expectIsoDiskFiles mounts the iso disk of the first volume of the VMI whose source matches at mountpoint
+// and verifies the content of each expected file
+func expectIsoDiskFiles(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFactory, kind string, matches func(source *v1.VolumeSource) bool, mountpoint string, expected map[string]string) error {
+	if len(expected) == 0 {
+		return fmt.Errorf("no files to verify")
+	}
+	volume := findVolume(vmi, matches)
+	if volume == nil {
+		return fmt.Errorf("VMI %s has no %s volume", vmi.Name, kind)
+	}
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if _, err := runCommandOnGuest(vmi, loginTo, mountIsoDiskCommand(vmi, volume, mountpoint), 30); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		// the files usually lack a trailing newline, which would put the prompt on the same line as the content
+		output, err := runCommandOnGuest(vmi, loginTo, fmt.Sprintf("cat %s/%s; echo", mountpoint, key), 15)
+		if err != nil {
+			return err
+		}
+		if actual := strings.TrimSpace(output); actual != strings.TrimSpace(expected[key]) {
+			return fmt.Errorf("expected %s/%s to contain %q, got %q", mountpoint, key, expected[key], actual)
+		}
+	}
+	return nil
+}
+
+func NewInt32(x int32) *int32 {
+	return &x
+}
+
+func NewRandomReplicaSetFromVMI(vmi *v1.VirtualMachineInstance, replicas int32) *v1.VirtualMachineInstanceReplicaSet {
+	name := "replicaset" + rand.String(5)
 	rs := &v1.VirtualMachineInstanceReplicaSet{
 		ObjectMeta: metav1.ObjectMeta{Name: "replicaset" + rand.String(5)},
 		Spec: v1.VirtualMachineInstanceReplicaSetSpec{
@@ -3098,6 +3887,103 @@ func NewRandomReplicaSetFromVMI(vmi *v1.VirtualMachineInstance, replicas int32)
 	return rs
 }
 
+// CreateReplicaSetAndWait creates the replica set and waits until all of its replicas are ready
+func CreateReplicaSetAndWait(rs *v1.VirtualMachineInstanceReplicaSet, timeoutSec int) *v1.VirtualMachineInstanceReplicaSet {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	namespace := rs.Namespace
+	if namespace == "" {
+		namespace = util2.NamespaceTestDefault
+	}
+	rs, err = virtCli.ReplicaSet(namespace).Create(rs)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+	replicas := int32(1)
+	if rs.Spec.Replicas != nil {
+		replicas = *rs.Spec.Replicas
+	}
+	return waitForReplicaSetReady(virtCli, namespace, rs.Name, replicas, timeoutSec)
+}
+
+// ScaleReplicaSetAndWait sets the replica count of the replica set and waits until that many replicas are ready
+func ScaleReplicaSetAndWait(name, namespace string, replicas int32, timeoutSec int) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	By(fmt.Sprintf("Scaling replica set %s to %d", name, replicas))
+	patch := fmt.Sprintf(`[{ "op": "replace", "path": "/spec/replicas", "value": %d }]`, replicas)
+	_, err = virtCli.ReplicaSet(namespace).Patch(name, types.JSONPatchType, []byte(patch))
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	waitForReplicaSetReady(virtCli, namespace, name, replicas, timeoutSec)
+}
+
+// ExpectReplicaSetRecreatesVMI waits until the replica set has replaced the VMI with deletedUID, that is until it
+// owns its desired number of VMIs again without counting the deleted one
+func ExpectReplicaSetRecreatesVMI(name, namespace string, deletedUID types.UID, timeoutSec int) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	rs, err := virtCli.ReplicaSet(namespace).Get(name, metav1.GetOptions{})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	selector, err := metav1.LabelSelectorAsSelector(rs.Spec.Selector)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	replicas := int32(1)
+	if rs.Spec.Replicas != nil {
+		replicas = *rs.Spec.Replicas
+	}
+
+	EventuallyWithOffset(1, func() (int32, error) {
+		vmis, err := virtCli.VirtualMachineInstance(namespace).List(&metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return 0, err
+		}
+		var replacements int32
+		for _, vmi := range NotDeleted(vmis) {
+			if vmi.UID != deletedUID {
+				replacements++
+			}
+		}
+		return replacements, nil
+	}, time.Duration(timeoutSec)*time.Second, time.Second).Should(Equal(replicas), "replica set %s should recreate the deleted VMI", name)
+}
+
+// CountOwnedVMIs returns how many VMIs in namespace have an owner reference to owner
+func CountOwnedVMIs(owner metav1.Object, namespace string) (int, error) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return 0, err
+	}
+
+	vmis, err := virtCli.VirtualMachineInstance(namespace).List(&metav1.ListOptions{})
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, vmi := range vmis.Items {
+		for _, ref := range vmi.OwnerReferences {
+			if ref.UID == owner.GetUID() {
+				count++
+				break
+			}
+		}
+	}
+	return count, nil
+}
+
+func waitForReplicaSetReady(virtCli kubecli.KubevirtClient, namespace, name string, replicas int32, timeoutSec int) *v1.VirtualMachineInstanceReplicaSet {
+	var rs *v1.VirtualMachineInstanceReplicaSet
+	EventuallyWithOffset(2, func() (int32, error) {
+		var err error
+		rs, err = virtCli.ReplicaSet(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		return rs.Status.ReadyReplicas, nil
+	}, time.Duration(timeoutSec)*time.Second, time.Second).Should(Equal(replicas), "replica set %s should have %d ready replicas", name, replicas)
+	return rs
+}
+
 func NewBool(x bool) *bool {
 	return &x
 }
@@ -3180,6 +4066,15 @@ func NewVirtctlCommand(args ...string) *cobra.Command {
 	return cmd
 }
 
+// RunVirtctlCommandWithOutput runs virtctl in-process and returns what the command printed
+func RunVirtctlCommandWithOutput(args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := NewVirtctlCommand(args...)
+	cmd.SetOut(&out)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
 func NewRepeatableVirtctlCommand(args ...string) func() error {
 	return func() error {
 		cmd := NewVirtctlCommand(args...)
@@ -3187,6 +4082,11 @@ func NewRepeatableVirtctlCommand(args ...string) func() error {
 	}
 }
 
+// ExpectVirtctlEventuallySucceeds retries the virtctl command until it succeeds or timeoutSec elapses
+func ExpectVirtctlEventuallySucceeds(timeoutSec int, args ...string) {
+	EventuallyWithOffset(1, NewRepeatableVirtctlCommand(args...), time.Duration(timeoutSec)*time.Second, time.Second).Should(Succeed())
+}
+
 func ExecuteCommandOnCephToolbox(virtCli kubecli.KubevirtClient, command []string) (string, error) {
 	pods, err := virtCli.CoreV1().Pods("rook-ceph").List(context.Background(), metav1.ListOptions{LabelSelector: "app=rook-ceph-tools"})
 	if err != nil {
@@ -3285,18 +4185,11 @@ func GetRunningVirtualMachineInstanceDomainXML(virtClient kubecli.KubevirtClient
 		return "", fmt.Errorf("Failed to get vmi, %s", err)
 	}
 
-	command := []string{"virsh"}
-	if kutil.IsNonRootVMI(freshVMI) {
-		command = append(command, "-c")
-		command = append(command, "qemu+unix:///session?socket=/var/run/libvirt/libvirt-sock")
-	}
-	command = append(command, []string{"dumpxml", vmi.Namespace + "_" + vmi.Name}...)
-
 	stdout, stderr, err := ExecuteCommandOnPodV2(
 		virtClient,
 		vmiPod,
 		vmiPod.Spec.Containers[containerIdx].Name,
-		command,
+		VirshCommand(freshVMI, "dumpxml", VMIDomainName(vmi)),
 	)
 	if err != nil {
 		return "", fmt.Errorf("could not dump libvirt domxml (remotely on pod): %v: %s", err, stderr)
@@ -3304,6 +4197,64 @@ func GetRunningVirtualMachineInstanceDomainXML(virtClient kubecli.KubevirtClient
 	return stdout, err
 }
 
+// IsVMINonRoot returns whether the VMI runs as an unprivileged user, with libvirt running in session mode
+func IsVMINonRoot(vmi *v1.VirtualMachineInstance) bool {
+	return kutil.IsNonRootVMI(vmi)
+}
+
+// VirshCommand returns the virsh command with args, connected to the libvirt instance of the VMI
+func VirshCommand(vmi *v1.VirtualMachineInstance, args ...string) []string {
+	command := []string{"virsh"}
+	if IsVMINonRoot(vmi) {
+		command = append(command, "-c", nonRootLibvirtURI)
+	}
+	return append(command, args...)
+}
+
+// ExpectVirshReachable verifies that virsh can connect to the libvirt instance of the VMI,
+// the session libvirt for non-root VMIs and the system libvirt otherwise
+func ExpectVirshReachable(vmi *v1.VirtualMachineInstance) error {
+	virtClient, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return err
+	}
+	freshVMI, err := virtClient.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to get vmi, %s", err)
+	}
+	vmiPod, err := getRunningPodByVirtualMachineInstance(freshVMI, freshVMI.Namespace)
+	if err != nil {
+		return err
+	}
+	_, stderr, err := ExecuteCommandOnPodV2(virtClient, vmiPod, "compute", VirshCommand(freshVMI, "version"))
+	if err != nil {
+		return fmt.Errorf("could not connect to libvirt with virsh (remotely on pod): %v: %s", err, stderr)
+	}
+	return nil
+}
+
+// VMIDomainName returns the name of the libvirt domain of the VMI
+func VMIDomainName(vmi *v1.VirtualMachineInstance) string {
+	return vmi.Namespace + "_" + vmi.Name
+}
+
+// CollectDomainXMLs returns the domain XML of every VMI by VMI name,
+// for VMIs whose domain XML can't be retrieved the error text is recorded instead
+func CollectDomainXMLs(vmis []v1.VirtualMachineInstance) map[string]string {
+	virtClient, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	domainXMLs := make(map[string]string, len(vmis))
+	for i := range vmis {
+		domainXML, err := GetRunningVirtualMachineInstanceDomainXML(virtClient, &vmis[i])
+		if err != nil {
+			domainXML = err.Error()
+		}
+		domainXMLs[vmis[i].Name] = domainXML
+	}
+	return domainXMLs
+}
+
 func LibvirtDomainIsPaused(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance) (bool, error) {
 	vmiPod, err := getRunningPodByVirtualMachineInstance(vmi, util2.NamespaceTestDefault)
 	if err != nil {
@@ -3322,11 +4273,17 @@ func LibvirtDomainIsPaused(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMac
 		return false, fmt.Errorf("could not find compute container for pod")
 	}
 
+	// get current vmi
+	freshVMI, err := virtClient.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("Failed to get vmi, %s", err)
+	}
+
 	stdout, stderr, err := ExecuteCommandOnPodV2(
 		virtClient,
 		vmiPod,
 		vmiPod.Spec.Containers[containerIdx].Name,
-		[]string{"virsh", "--quiet", "domstate", vmi.Namespace + "_" + vmi.Name},
+		VirshCommand(freshVMI, "--quiet", "domstate", VMIDomainName(vmi)),
 	)
 	if err != nil {
 		return false, fmt.Errorf("could not get libvirt domstate (remotely on pod): %v: %s", err, stderr)
@@ -3352,16 +4309,122 @@ func LibvirtDomainIsPersistent(virtClient kubecli.KubevirtClient, vmi *v1.Virtua
 		return false, fmt.Errorf("could not find compute container for pod")
 	}
 
+	// get current vmi
+	freshVMI, err := virtClient.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("Failed to get vmi, %s", err)
+	}
+
 	stdout, stderr, err := ExecuteCommandOnPodV2(
 		virtClient,
 		vmiPod,
 		vmiPod.Spec.Containers[containerIdx].Name,
-		[]string{"virsh", "--quiet", "list", "--persistent", "--name"},
+		VirshCommand(freshVMI, "--quiet", "list", "--persistent", "--name"),
 	)
 	if err != nil {
 		return false, fmt.Errorf("could not dump libvirt domxml (remotely on pod): %v: %s", err, stderr)
 	}
-	return strings.Contains(stdout, vmi.Namespace+"_"+vmi.Name), nil
+	return strings.Contains(stdout, VMIDomainName(vmi)), nil
+}
+
+// WaitForDomainPersistent waits until the libvirt domain of the VMI is listed as persistent
+func WaitForDomainPersistent(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance, timeoutSec int) {
+	EventuallyWithOffset(1, func() bool {
+		persistent, err := LibvirtDomainIsPersistent(virtClient, vmi)
+		return err == nil && persistent
+	}, time.Duration(timeoutSec)*time.Second, time.Second).Should(BeTrue(), "The VMI was not found in the list of libvirt persistent domains")
+}
+
+// ExpectVNCConnectable opens the VNC subresource of the VMI and verifies that
+// the RFB protocol version handshake is received from the VNC server
+func ExpectVNCConnectable(vmi *v1.VirtualMachineInstance) error {
+	virtClient, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return err
+	}
+
+	vnc, err := virtClient.VirtualMachineInstance(vmi.Namespace).VNC(vmi.Name)
+	if err != nil {
+		return err
+	}
+
+	handshake, err := readFirstResponseFromStream(vnc, "", 45*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to receive the VNC server handshake: %v", err)
+	}
+	if !strings.HasPrefix(handshake, "RFB ") {
+		return fmt.Errorf("unexpected response from VNC server: %q", handshake)
+	}
+	return nil
+}
+
+// ExpectSerialConsoleConnectable opens the serial console subresource of the VMI,
+// sends a newline and verifies that the guest answers with any output
+func ExpectSerialConsoleConnectable(vmi *v1.VirtualMachineInstance) error {
+	virtClient, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return err
+	}
+
+	serialConsole, err := virtClient.VirtualMachineInstance(vmi.Namespace).SerialConsole(vmi.Name, &kubecli.SerialConsoleOptions{ConnectionTimeout: 30 * time.Second})
+	if err != nil {
+		return err
+	}
+
+	if _, err := readFirstResponseFromStream(serialConsole, "\n", 60*time.Second); err != nil {
+		return fmt.Errorf("failed to receive output from the serial console: %v", err)
+	}
+	return nil
+}
+
+// readFirstResponseFromStream streams the subresource, optionally sends input to it
+// and returns the first chunk of data received. The stream is closed on return.
+func readFirstResponseFromStream(stream kubecli.StreamInterface, input string, timeout time.Duration) (string, error) {
+	pipeInReader, pipeInWriter := io.Pipe()
+	pipeOutReader, pipeOutWriter := io.Pipe()
+	defer pipeInWriter.Close()
+	defer pipeOutReader.Close()
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- stream.Stream(kubecli.StreamOptions{
+			In:  pipeInReader,
+			Out: pipeOutWriter,
+		})
+	}()
+
+	if input != "" {
+		go func() {
+			// the write fails once the stream is closed, nothing to report then
+			_, _ = pipeInWriter.Write([]byte(input))
+		}()
+	}
+
+	response := make(chan string, 1)
+	readErr := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1024)
+		n, err := pipeOutReader.Read(buf)
+		if n == 0 && err != nil {
+			readErr <- err
+			return
+		}
+		response <- string(buf[0:n])
+	}()
+
+	select {
+	case data := <-response:
+		return data, nil
+	case err := <-readErr:
+		return "", err
+	case err := <-streamErr:
+		if err == nil {
+			err = fmt.Errorf("stream closed")
+		}
+		return "", err
+	case <-time.After(timeout):
+		return "", fmt.Errorf("timed out after %v", timeout)
+	}
 }
 
 func BeforeAll(fn func()) {
@@ -4081,6 +5144,255 @@ func StartVmOnNode(vmi *v1.VirtualMachineInstance, nodeName string) *v1.VirtualM
 	return vmi
 }
 
+// ExpectVMIPreempted waits for the pod of the VMI to be preempted by the scheduler and deleted
+func ExpectVMIPreempted(vmi *v1.VirtualMachineInstance, timeoutSec int) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+	pods, err := virtClient.CoreV1().Pods(vmi.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: v1.CreatedByLabel + "=" + string(vmi.GetUID()),
+	})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, pods.Items).To(HaveLen(1), fmt.Sprintf("VMI %s should have exactly one pod", vmi.Name))
+	pod := &pods.Items[0]
+
+	timeout := time.Duration(timeoutSec) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	By("Waiting for the preemption event on the VMI pod")
+	NewObjectEventWatcher(pod).Timeout(timeout).SinceWatchedObjectResourceVersion().WaitFor(ctx, NormalEvent, "Preempted")
+
+	By("Waiting for the VMI pod to be deleted")
+	EventuallyWithOffset(1, func() bool {
+		_, err := virtClient.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+		return errors.IsNotFound(err)
+	}, timeout, time.Second).Should(BeTrue(), fmt.Sprintf("pod %s should be deleted after preemption", pod.Name))
+}
+
+// ExpectLauncherPodAnnotation verifies that the launcher pod of the VMI carries the annotation key with value
+func ExpectLauncherPodAnnotation(vmi *v1.VirtualMachineInstance, key, value string) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	pod, err := getLauncherPod(virtCli, vmi)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, pod.Annotations).To(HaveKeyWithValue(key, value), "launcher pod %s should have annotation %s=%s", pod.Name, key, value)
+}
+
+// ExpectLauncherPodLabel verifies that the launcher pod of the VMI carries the label key with value
+func ExpectLauncherPodLabel(vmi *v1.VirtualMachineInstance, key, value string) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	pod, err := getLauncherPod(virtCli, vmi)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, pod.Labels).To(HaveKeyWithValue(key, value), "launcher pod %s should have label %s=%s", pod.Name, key, value)
+}
+
+// ExpectLauncherPodQOS verifies the QoS class assigned to the launcher pod of the VMI
+func ExpectLauncherPodQOS(vmi *v1.VirtualMachineInstance, expected k8sv1.PodQOSClass) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	pod, err := getLauncherPod(virtCli, vmi)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, pod.Status.QOSClass).To(Equal(expected), "launcher pod %s should have QoS class %s", pod.Name, expected)
+}
+
+// ExpectLauncherPodRuntimeClass verifies the RuntimeClass of the launcher pod of the VMI,
+// an empty expected name means that no RuntimeClass is set
+func ExpectLauncherPodRuntimeClass(vmi *v1.VirtualMachineInstance, expected string) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	pod, err := getLauncherPod(virtCli, vmi)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	runtimeClassName := ""
+	if pod.Spec.RuntimeClassName != nil {
+		runtimeClassName = *pod.Spec.RuntimeClassName
+	}
+	ExpectWithOffset(1, runtimeClassName).To(Equal(expected), "launcher pod %s should have RuntimeClass %q", pod.Name, expected)
+}
+
+// ExpectLauncherPodGracePeriod verifies the termination grace period of the launcher pod of the VMI,
+// an unset grace period is taken as the Kubernetes default
+func ExpectLauncherPodGracePeriod(vmi *v1.VirtualMachineInstance, expected int64) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	pod, err := getLauncherPod(virtCli, vmi)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	gracePeriod := int64(k8sv1.DefaultTerminationGracePeriodSeconds)
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		gracePeriod = *pod.Spec.TerminationGracePeriodSeconds
+	}
+	ExpectWithOffset(1, gracePeriod).To(Equal(expected), "launcher pod %s should have a termination grace period of %d seconds", pod.Name, expected)
+}
+
+// GetLauncherPodMemoryOverhead returns how much memory the compute container of the launcher pod
+// requests on top of the memory requested by the VMI
+func GetLauncherPodMemoryOverhead(vmi *v1.VirtualMachineInstance) (resource.Quantity, error) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+	pod, err := getLauncherPod(virtCli, vmi)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+	return launcherPodMemoryOverhead(pod, vmi)
+}
+
+func launcherPodMemoryOverhead(pod *k8sv1.Pod, vmi *v1.VirtualMachineInstance) (resource.Quantity, error) {
+	vmiMemory, exists := vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory]
+	if !exists {
+		return resource.Quantity{}, fmt.Errorf("VMI %s does not request memory", vmi.Name)
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name != "compute" {
+			continue
+		}
+		overhead, exists := container.Resources.Requests[k8sv1.ResourceMemory]
+		if !exists {
+			return resource.Quantity{}, fmt.Errorf("compute container of pod %s does not request memory", pod.Name)
+		}
+		overhead = overhead.DeepCopy()
+		overhead.Sub(vmiMemory)
+		return overhead, nil
+	}
+	return resource.Quantity{}, fmt.Errorf("pod %s has no compute container", pod.Name)
+}
+
+// getLauncherPod returns the active launcher pod of the VMI
+func getLauncherPod(virtCli kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance) (*k8sv1.Pod, error) {
+	pods, err := virtCli.CoreV1().Pods(vmi.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", v1.CreatedByLabel, string(vmi.GetUID())),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase != k8sv1.PodSucceeded && pods.Items[i].Status.Phase != k8sv1.PodFailed {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no active launcher pod found for VMI %s", vmi.Name)
+}
+
+// ExpectVMILiveMigratable verifies that the LiveMigratable condition of the VMI is set with the expected status
+func ExpectVMILiveMigratable(vmi *v1.VirtualMachineInstance, expected bool) {
+	status := k8sv1.ConditionFalse
+	if expected {
+		status = k8sv1.ConditionTrue
+	}
+	condition := controller.NewVirtualMachineInstanceConditionManager().GetCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
+	ExpectWithOffset(1, condition).ToNot(BeNil(), "VMI %s should have the %s condition", vmi.Name, v1.VirtualMachineInstanceIsMigratable)
+	if condition != nil {
+		ExpectWithOffset(1, condition.Status).To(Equal(status), "VMI %s should have the %s condition set to %s", vmi.Name, v1.VirtualMachineInstanceIsMigratable, status)
+	}
+}
+
+// GetMigrationMethod returns the migration method, live or block, the VMI will be migrated with
+func GetMigrationMethod(vmi *v1.VirtualMachineInstance) (v1.VirtualMachineInstanceMigrationMethod, error) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return "", err
+	}
+
+	vmi, err = virtCli.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if vmi.Status.MigrationMethod == "" {
+		return "", fmt.Errorf("VMI %s has no migration method set", vmi.Name)
+	}
+	return vmi.Status.MigrationMethod, nil
+}
+
+// WaitForVMIEvictionStarted waits until the eviction of the active launcher pod of the VMI has started,
+// that is until the pod is marked with the eviction-in-progress annotation, the VMI is marked for evacuation,
+// or the pod is being deleted or gone
+func WaitForVMIEvictionStarted(vmi *v1.VirtualMachineInstance, timeoutSec int) {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	launcherPod, err := getLauncherPod(virtCli, vmi)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+	EventuallyWithOffset(1, func() (bool, error) {
+		pod, err := virtCli.CoreV1().Pods(launcherPod.Namespace).Get(context.Background(), launcherPod.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		} else if err != nil {
+			return false, err
+		}
+		if _, annotated := pod.Annotations[evictionInProgressAnnotation]; annotated || pod.DeletionTimestamp != nil {
+			return true, nil
+		}
+
+		updatedVMI, err := virtCli.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return updatedVMI.Status.EvacuationNodeName != "", nil
+	}, time.Duration(timeoutSec)*time.Second, time.Second).Should(BeTrue(), "launcher pod %s of VMI %s should be evicted", launcherPod.Name, vmi.Name)
+}
+
+// ExposeVMIAsService creates a service selecting the VMI's launcher pod and waits until
+// the service has at least one ready endpoint
+func ExposeVMIAsService(vmi *v1.VirtualMachineInstance, serviceName string, port int32, svcType k8sv1.ServiceType) *k8sv1.Service {
+	virtCli, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	service, err := virtCli.CoreV1().Services(vmi.Namespace).Create(context.Background(), &k8sv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName},
+		Spec: k8sv1.ServiceSpec{
+			Type:     svcType,
+			Selector: map[string]string{v1.CreatedByLabel: string(vmi.UID)},
+			Ports: []k8sv1.ServicePort{
+				{Protocol: k8sv1.ProtocolTCP, Port: port, TargetPort: intstr.FromInt(int(port))},
+			},
+		},
+	}, metav1.CreateOptions{})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+	EventuallyWithOffset(1, func() int {
+		endpoints, err := virtCli.CoreV1().Endpoints(vmi.Namespace).Get(context.Background(), serviceName, metav1.GetOptions{})
+		if err != nil {
+			return 0
+		}
+		readyAddresses := 0
+		for _, subset := range endpoints.Subsets {
+			readyAddresses += len(subset.Addresses)
+		}
+		return readyAddresses
+	}, 120*time.Second, 1*time.Second).ShouldNot(BeZero(), "service %s should have a ready endpoint", serviceName)
+
+	return service
+}
+
+// ExpectVMIsColocated verifies that both VMIs were scheduled on the same node
+// if colocated is true, or on different nodes otherwise
+func ExpectVMIsColocated(a, b *v1.VirtualMachineInstance, colocated bool) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+	a, err = virtClient.VirtualMachineInstance(a.Namespace).Get(a.Name, &metav1.GetOptions{})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	b, err = virtClient.VirtualMachineInstance(b.Namespace).Get(b.Name, &metav1.GetOptions{})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+	ExpectWithOffset(1, a.Status.NodeName).ToNot(BeEmpty(), fmt.Sprintf("VMI %s should be scheduled", a.Name))
+	ExpectWithOffset(1, b.Status.NodeName).ToNot(BeEmpty(), fmt.Sprintf("VMI %s should be scheduled", b.Name))
+	if colocated {
+		ExpectWithOffset(1, a.Status.NodeName).To(Equal(b.Status.NodeName), fmt.Sprintf("VMIs %s and %s should run on the same node", a.Name, b.Name))
+	} else {
+		ExpectWithOffset(1, a.Status.NodeName).ToNot(Equal(b.Status.NodeName), fmt.Sprintf("VMIs %s and %s should run on different nodes", a.Name, b.Name))
+	}
+}
+
 // RunCommandOnVmiPod runs specified command on the virt-launcher pod
 func RunCommandOnVmiPod(vmi *v1.VirtualMachineInstance, command []string) string {
 	virtClient, err := kubecli.GetKubevirtClient()
@@ -4360,6 +5672,25 @@ func GetRunningVMIDomainSpec(vmi *v1.VirtualMachineInstance) (*launcherApi.Domai
 	return &runningVMISpec, err
 }
 
+// GetDomainMachineType returns the emulated machine type of the running domain
+func GetDomainMachineType(vmi *v1.VirtualMachineInstance) (string, error) {
+	domSpec, err := GetRunningVMIDomainSpec(vmi)
+	if err != nil {
+		return "", err
+	}
+	return parse.DomainMachineType(domSpec), nil
+}
+
+// GetDomainHyperVFeatures returns the state of the Hyper-V enlightenments present in the running domain,
+// keyed by their VMI API names
+func GetDomainHyperVFeatures(vmi *v1.VirtualMachineInstance) (map[string]bool, error) {
+	domSpec, err := GetRunningVMIDomainSpec(vmi)
+	if err != nil {
+		return nil, err
+	}
+	return parse.DomainHyperVFeatures(domSpec), nil
+}
+
 func ForwardPorts(pod *k8sv1.Pod, ports []string, stop chan struct{}, readyTimeout time.Duration) error {
 	errChan := make(chan error, 1)
 	readyChan := make(chan struct{})
@@ -4424,6 +5755,28 @@ func GenerateHelloWorldServer(vmi *v1.VirtualMachineInstance, testPort int, prot
 	}, 60)).To(Succeed())
 }
 
+// ExpectSlirpPortForwarded verifies that port is listening inside the compute container of the
+// VMI's virt-launcher pod, which is where SLIRP forwards the ports exposed by the interface
+func ExpectSlirpPortForwarded(vmi *v1.VirtualMachineInstance, port int) error {
+	virtCli, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return err
+	}
+	vmiPod, err := getRunningPodByVirtualMachineInstance(vmi, vmi.Namespace)
+	if err != nil {
+		return err
+	}
+
+	output, err := ExecuteCommandOnPod(virtCli, vmiPod, "compute", []string{"cat", "/proc/net/tcp", "/proc/net/tcp6"})
+	if err != nil {
+		return err
+	}
+	if !parse.TCPPortListening(output, port) {
+		return fmt.Errorf("port %d is not listening in the virt-launcher pod of VMI %s", port, vmi.Name)
+	}
+	return nil
+}
+
 // UpdateClusterConfigValueAndWait updates the given configuration in the kubevirt config map and then waits
 // to allow the configuration events to be propagated to the consumers.
 func UpdateClusterConfigValueAndWait(key string, value string) string {
@@ -4623,6 +5976,96 @@ func WaitForVMICondition(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachi
 	}, time.Duration(timeoutSec)*time.Second, 2).Should(BeTrue(), fmt.Sprintf("Should have %s condition", conditionType))
 }
 
+// WaitForVMIReady waits until the Ready condition of the VMI is true, which unlike the Running phase
+// also reflects the readiness probes
+func WaitForVMIReady(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance, timeoutSec int) {
+	WaitForVMICondition(virtClient, vmi, v1.VirtualMachineInstanceReady, timeoutSec)
+}
+
+// WaitForAccessCredentialsSynced waits until the guest agent reports the access credentials of the VMI as synchronized
+func WaitForAccessCredentialsSynced(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance, timeoutSec int) {
+	WaitForVMICondition(virtClient, vmi, v1.VirtualMachineInstanceAccessCredentialsSynchronized, timeoutSec)
+}
+
+// WaitForGuestOSInfo waits until the guest agent reported the OS name of the VMI and returns the reported OS info
+func WaitForGuestOSInfo(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance, timeoutSec int) v1.VirtualMachineInstanceGuestOSInfo {
+	By("Waiting for the guest OS info to be reported")
+	var guestOSInfo v1.VirtualMachineInstanceGuestOSInfo
+	EventuallyWithOffset(1, func() string {
+		updatedVmi, err := virtClient.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		guestOSInfo = updatedVmi.Status.GuestOSInfo
+		return guestOSInfo.Name
+	}, time.Duration(timeoutSec)*time.Second, time.Second).ShouldNot(BeEmpty(), "Should have the guest OS info reported")
+	return guestOSInfo
+}
+
+// PauseVMIAndWait pauses the VMI and waits until both the Paused condition and the libvirt domain report it as paused
+func PauseVMIAndWait(vmi *v1.VirtualMachineInstance, timeoutSec int) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	By(fmt.Sprintf("Pausing VMI %s", vmi.Name))
+	ExpectWithOffset(1, virtClient.VirtualMachineInstance(vmi.Namespace).Pause(vmi.Name)).To(Succeed())
+	WaitForVMICondition(virtClient, vmi, v1.VirtualMachineInstancePaused, timeoutSec)
+	waitForLibvirtDomainPaused(virtClient, vmi, true, timeoutSec)
+}
+
+// UnpauseVMIAndWait unpauses the VMI and waits until both the Paused condition and the libvirt domain report it as running
+func UnpauseVMIAndWait(vmi *v1.VirtualMachineInstance, timeoutSec int) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	By(fmt.Sprintf("Unpausing VMI %s", vmi.Name))
+	ExpectWithOffset(1, virtClient.VirtualMachineInstance(vmi.Namespace).Unpause(vmi.Name)).To(Succeed())
+	WaitForVMIConditionRemovedOrFalse(virtClient, vmi, v1.VirtualMachineInstancePaused, timeoutSec)
+	waitForLibvirtDomainPaused(virtClient, vmi, false, timeoutSec)
+}
+
+// ExpectVMIStartedPaused waits until the VMI, started with the Paused start strategy, is running
+// but paused according to both its Paused condition and the libvirt domain
+func ExpectVMIStartedPaused(vmi *v1.VirtualMachineInstance, timeoutSec int) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	WaitForSuccessfulVMIStartWithTimeout(vmi, timeoutSec)
+	WaitForVMICondition(virtClient, vmi, v1.VirtualMachineInstancePaused, timeoutSec)
+	waitForLibvirtDomainPaused(virtClient, vmi, true, timeoutSec)
+}
+
+func waitForLibvirtDomainPaused(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance, paused bool, timeoutSec int) {
+	EventuallyWithOffset(2, func() (bool, error) {
+		return LibvirtDomainIsPaused(virtClient, vmi)
+	}, time.Duration(timeoutSec)*time.Second, time.Second).Should(Equal(paused), "libvirt domain of VMI %s should be paused: %t", vmi.Name, paused)
+}
+
+// FreezeVMIAndWait freezes the guest filesystems through the guest agent and waits until the freeze is reported
+func FreezeVMIAndWait(vmi *v1.VirtualMachineInstance, timeoutSec int) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	By(fmt.Sprintf("Freezing VMI %s", vmi.Name))
+	ExpectWithOffset(1, virtClient.VirtualMachineInstance(vmi.Namespace).Freeze(vmi.Name)).To(Succeed())
+	waitForFSFreezeStatus(virtClient, vmi, launcherApi.FSFrozen, timeoutSec)
+}
+
+// UnfreezeVMI thaws the guest filesystems through the guest agent and waits until the thaw is reported
+func UnfreezeVMI(vmi *v1.VirtualMachineInstance) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	util2.PanicOnError(err)
+
+	By(fmt.Sprintf("Unfreezing VMI %s", vmi.Name))
+	ExpectWithOffset(1, virtClient.VirtualMachineInstance(vmi.Namespace).Unfreeze(vmi.Name)).To(Succeed())
+	waitForFSFreezeStatus(virtClient, vmi, launcherApi.FSThawed, 30)
+}
+
+func waitForFSFreezeStatus(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance, status string, timeoutSec int) {
+	EventuallyWithOffset(2, func() (string, error) {
+		guestInfo, err := virtClient.VirtualMachineInstance(vmi.Namespace).GuestOsInfo(vmi.Name)
+		return guestInfo.FSFreezeStatus, err
+	}, time.Duration(timeoutSec)*time.Second, time.Second).Should(Equal(status), "guest filesystems of VMI %s should be %s", vmi.Name, status)
+}
+
 func WaitForVMIConditionRemovedOrFalse(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance, conditionType v1.VirtualMachineInstanceConditionType, timeoutSec int) {
 	By(fmt.Sprintf("Waiting for %s condition removed or false", conditionType))
 	EventuallyWithOffset(1, func() bool {
@@ -4821,6 +6264,51 @@ func CallUrlOnPod(pod *k8sv1.Pod, port string, url string) ([]byte, error) {
 	return ioutil.ReadAll(resp.Body)
 }
 
+// GetVMIMetrics scrapes the metrics endpoint of the virt-handler on the node of the VMI and returns
+// the series labeled with the VMI, keyed by the series name including its labels
+func GetVMIMetrics(vmi *v1.VirtualMachineInstance) (map[string]float64, error) {
+	virtClient, err := kubecli.GetKubevirtClient()
+	if err != nil {
+		return nil, err
+	}
+	vmi, err = virtClient.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	handlerPod, err := kubecli.NewVirtHandlerClient(virtClient).Namespace(flags.KubeVirtInstallNamespace).ForNode(vmi.Status.NodeName).Pod()
+	if err != nil {
+		return nil, err
+	}
+	output, err := CallUrlOnPod(handlerPod, "8443", "metrics")
+	if err != nil {
+		return nil, err
+	}
+	return parse.VMIMetrics(string(output), vmi.Namespace, vmi.Name)
+}
+
+// ExpectMetricGreaterThan waits until a series of metricName scraped for the VMI exceeds threshold
+func ExpectMetricGreaterThan(vmi *v1.VirtualMachineInstance, metricName string, threshold float64, timeoutSec int) {
+	EventuallyWithOffset(1, func() (float64, error) {
+		metrics, err := GetVMIMetrics(vmi)
+		if err != nil {
+			return 0, err
+		}
+		max, found := 0.0, false
+		for series, value := range metrics {
+			if series != metricName && !strings.HasPrefix(series, metricName+"{") {
+				continue
+			}
+			if !found || value > max {
+				max, found = value, true
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("metric %s not found", metricName)
+		}
+		return max, nil
+	}, time.Duration(timeoutSec)*time.Second, 2*time.Second).Should(BeNumerically(">", threshold), "metric %s should exceed %v", metricName, threshold)
+}
+
 // GetCertsForPods returns the used certificates for all pods matching  the label selector
 func GetCertsForPods(labelSelector string, namespace string, port string) ([][]byte, error) {
 	cli, err := kubecli.GetKubevirtClient()
@@ -253,6 +253,13 @@ var _ = Describe("[rfe_id:151][crit:high][vendor:cnv-qe@redhat.com][level:compon
 					&expect.BExp{R: dns.SanitizeHostname(vmi)},
 				}, 10)).To(Succeed())
 			})
+
+			It("should apply the VMI hostname in the guest", func() {
+				vmi := tests.NewRandomVMIWithHostname("testhostname", "testsubdomain")
+				vmi = LaunchVMI(vmi)
+
+				Expect(tests.ExpectGuestHostname(vmi, libnet.WithIPv6(console.LoginToCirros), "testhostname")).To(Succeed())
+			})
 		})
 
 		Context("with cloudInitConfigDrive userData source", func() {
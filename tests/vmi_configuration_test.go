@@ -322,6 +322,23 @@ var _ = Describe("[sig-compute]Configurations", func() {
 				}, 60)).To(Succeed(), "should report number of sockets")
 			})
 
+			It("should report the requested CPU topology under guest OS", func() {
+				vmi = tests.NewRandomFedoraVMI()
+				vmi.Spec.Domain.CPU = &v1.CPU{
+					Sockets: 3,
+					Cores:   1,
+					Threads: 1,
+				}
+
+				By("Starting a VirtualMachineInstance")
+				vmi, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(vmi)
+				Expect(err).ToNot(HaveOccurred(), "should start vmi")
+				tests.WaitForSuccessfulVMIStart(vmi)
+
+				Expect(tests.GetGuestCPUCount(vmi, console.LoginToFedora)).To(Equal(3))
+				Expect(tests.ExpectGuestCPUTopology(vmi, console.LoginToFedora, 3, 1, 1)).To(Succeed())
+			})
+
 			It("[test_id:1661]should report 2 sockets from spec.domain.resources.requests under guest OS ", func() {
 				vmi.Spec.Domain.CPU = nil
 				vmi.Spec.Domain.Resources = v1.ResourceRequirements{
@@ -605,6 +622,16 @@ var _ = Describe("[sig-compute]Configurations", func() {
 			})
 		})
 
+		Context("with 512Mi of requested memory", func() {
+			It("should show approximately the requested memory inside the VMI", func() {
+				vmi := tests.NewRandomVMIWithEphemeralDiskAndUserdata(cd.ContainerDiskFor(cd.ContainerDiskCirros), "#!/bin/bash\necho 'hello'\n")
+				vmi.Spec.Domain.Resources.Requests[kubev1.ResourceMemory] = resource.MustParse("512Mi")
+
+				vmi = tests.RunVMIAndExpectLaunch(vmi, 60)
+				Expect(tests.ExpectGuestMemoryApprox(vmi, console.LoginToCirros, 512, 10)).To(Succeed())
+			})
+		})
+
 		Context("[rfe_id:140][crit:medium][vendor:cnv-qe@redhat.com][level:component]with diverging memory limit from memory request and no guest memory", func() {
 			It("[test_id:3115]should show the memory limit inside the VMI", func() {
 				vmi := tests.NewRandomVMIWithEphemeralDiskAndUserdata(cd.ContainerDiskFor(cd.ContainerDiskCirros), "#!/bin/bash\necho 'hello'\n")
@@ -1080,6 +1107,21 @@ var _ = Describe("[sig-compute]Configurations", func() {
 				table.Entry("[Serial][test_id:1672]hugepages-1Gi", "2Mi", "70Mi", "64Mi"),
 			)
 
+			It("should report the hugepage size under guest OS", func() {
+				hugepageType := kubev1.ResourceName(kubev1.ResourceHugePagesPrefix + "2Mi")
+				if tests.GetNodeWithHugepages(virtClient, hugepageType) == nil {
+					Skip(fmt.Sprintf("No node with hugepages %s capacity", hugepageType))
+				}
+
+				By("Starting a VM")
+				hugepagesVmi = tests.NewRandomVMIWithHugepages("2Mi", "64Mi")
+				hugepagesVmi, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(hugepagesVmi)
+				Expect(err).ToNot(HaveOccurred())
+				tests.WaitForSuccessfulVMIStart(hugepagesVmi)
+
+				Expect(tests.ExpectGuestHugepages(hugepagesVmi, console.LoginToCirros, 2048)).To(Succeed())
+			})
+
 			Context("with unsupported page size", func() {
 				It("[test_id:1673]should failed to schedule the pod", func() {
 					nodes, err := virtClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
@@ -1148,6 +1190,15 @@ var _ = Describe("[sig-compute]Configurations", func() {
 				}, 400)).To(Succeed())
 			})
 
+			It("should expose the virtio rng device as hardware random source", func() {
+				rngVmi = tests.NewRandomVMIWithRNG()
+				rngVmi, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(rngVmi)
+				Expect(err).ToNot(HaveOccurred())
+				tests.WaitForSuccessfulVMIStart(rngVmi)
+
+				Expect(tests.ExpectRNGInGuest(rngVmi, console.LoginToAlpine)).To(Succeed())
+			})
+
 			It("[test_id:1675]should not have the virtio rng device when not present", func() {
 				By("Starting a VirtualMachineInstance")
 				rngVmi, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(rngVmi)
@@ -1232,6 +1283,13 @@ var _ = Describe("[sig-compute]Configurations", func() {
 
 			})
 
+			It("should freeze and thaw the guest filesystems", func() {
+				agentVMI := prepareAgentVM()
+
+				tests.FreezeVMIAndWait(agentVMI, 30)
+				tests.UnfreezeVMI(agentVMI)
+			})
+
 			It("[test_id:4625]should remove condition when agent is off", func() {
 				agentVMI := prepareAgentVM()
 				getOptions := metav1.GetOptions{}
@@ -1616,8 +1674,7 @@ var _ = Describe("[sig-compute]Configurations", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				By("Checking for presence of runtimeClassName")
-				pod := tests.GetPodByVirtualMachineInstance(vmi)
-				Expect(*pod.Spec.RuntimeClassName).To(BeEquivalentTo(runtimeClassName))
+				tests.ExpectLauncherPodRuntimeClass(vmi, runtimeClassName)
 			})
 
 			It("should not apply runtimeClassName to pod when not set", func() {
@@ -1630,8 +1687,7 @@ var _ = Describe("[sig-compute]Configurations", func() {
 				tests.WaitForSuccessfulVMIStart(vmi)
 
 				By("Checking for absence of runtimeClassName")
-				pod := tests.GetRunningPodByVirtualMachineInstance(vmi, util.NamespaceTestDefault)
-				Expect(pod.Spec.RuntimeClassName).To(BeNil())
+				tests.ExpectLauncherPodRuntimeClass(vmi, "")
 			})
 		})
 	})
@@ -1694,6 +1750,19 @@ var _ = Describe("[sig-compute]Configurations", func() {
 					&expect.BExp{R: fmt.Sprintf(".*model name.*%s.*", niceName)},
 				}, 10)).To(Succeed())
 			})
+
+			It("should report the CPU model requested through the test helper", func() {
+				supportedCPUs := tests.GetSupportedCPUModels(*nodes)
+				Expect(supportedCPUs).ToNot(BeEmpty())
+				cpuVmi = tests.NewRandomVMIWithCPUModel(supportedCPUs[0])
+
+				By("Starting a VirtualMachineInstance")
+				cpuVmi, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(cpuVmi)
+				Expect(err).ToNot(HaveOccurred())
+				tests.WaitForSuccessfulVMIStart(cpuVmi)
+
+				Expect(tests.ExpectGuestCPUModel(cpuVmi, libnet.WithIPv6(console.LoginToCirros), parseCPUNiceName(supportedCPUs[0]))).To(Succeed())
+			})
 		})
 
 		Context("[rfe_id:140][crit:medium][vendor:cnv-qe@redhat.com][level:component]when CPU model equals to passthrough", func() {
@@ -1721,6 +1790,17 @@ var _ = Describe("[sig-compute]Configurations", func() {
 					&expect.BExp{R: fmt.Sprintf(".*model name.*%s.*", niceName)},
 				}, 10)).To(Succeed())
 			})
+
+			It("should expose the node CPU features to the guest", func() {
+				cpuVmi = tests.NewRandomVMIWithHostPassthroughCPU()
+
+				By("Starting a VirtualMachineInstance")
+				cpuVmi, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(cpuVmi)
+				Expect(err).ToNot(HaveOccurred())
+				tests.WaitForSuccessfulVMIStart(cpuVmi)
+
+				Expect(tests.ExpectGuestCPUFeature(cpuVmi, libnet.WithIPv6(console.LoginToCirros), "sse2")).To(Succeed())
+			})
 		})
 
 		Context("[rfe_id:140][crit:medium][vendor:cnv-qe@redhat.com][level:component]when CPU model not defined", func() {
@@ -2026,6 +2106,18 @@ var _ = Describe("[sig-compute]Configurations", func() {
 			Expect(disks[0].BlockIO.PhysicalBlockSize).To(Equal(physicalSize))
 		})
 
+		It("should present the custom block sizes to the guest", func() {
+			vmi := tests.NewRandomVMIWithEphemeralDisk(cd.ContainerDiskFor(cd.ContainerDiskAlpine))
+			tests.AppendEmptyDisk(vmi, "emptydisk", "virtio", "1Gi")
+			Expect(libvmi.SetDiskBlockSize(vmi, "emptydisk", 4096, 4096)).To(Succeed())
+
+			By("initializing the VM")
+			vmi = tests.RunVMIAndExpectLaunch(vmi, 60)
+
+			By("checking the block sizes inside the guest")
+			Expect(tests.ExpectGuestDiskBlockSize(vmi, console.LoginToAlpine, "vdb", 4096, 4096)).To(Succeed())
+		})
+
 		It("[test_id:6966]Should set BlockIO when set to match volume block sizes on block devices", func() {
 			By("creating a block volume")
 			tests.CreateBlockVolumePvAndPvc("1Gi")
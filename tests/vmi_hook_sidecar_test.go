@@ -26,11 +26,11 @@ import (
 	"time"
 
 	"kubevirt.io/kubevirt/pkg/hooks"
-	putil "kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/tests/util"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	k8sv1 "k8s.io/api/core/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -142,6 +142,35 @@ var _ = Describe("[sig-compute]HookSidecars", func() {
 			}, 30)
 		})
 
+		Context("with a debug sidecar", func() {
+			It("should add the sidecar to the VMI pod without sharing its PID namespace", func() {
+				image := fmt.Sprintf("%s/%s:%s", flags.KubeVirtUtilityRepoPrefix, hookSidecarImage, flags.KubeVirtUtilityVersionTag)
+				vmi = tests.NewRandomVMIWithEphemeralDisk(cd.ContainerDiskFor(cd.ContainerDiskAlpine))
+				tests.AddDebugSidecar(vmi, image)
+				Expect(vmi.Annotations).To(HaveKey(hooks.HookSidecarListAnnotationName))
+
+				By("Starting a VMI")
+				vmi, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				var vmiPod *k8sv1.Pod
+				Eventually(func() error {
+					vmiPod, err = getVMIPod(vmi)
+					return err
+				}, 30*time.Second, time.Second).Should(Succeed())
+
+				var sidecar *k8sv1.Container
+				for i := range vmiPod.Spec.Containers {
+					if vmiPod.Spec.Containers[i].Name == sidecarContainerName {
+						sidecar = &vmiPod.Spec.Containers[i]
+					}
+				}
+				Expect(sidecar).ToNot(BeNil(), "the VMI pod should have a %s container", sidecarContainerName)
+				Expect(sidecar.Image).To(Equal(image))
+				Expect(vmiPod.Spec.ShareProcessNamespace).To(Or(BeNil(), PointTo(BeFalse())))
+			}, 60)
+		})
+
 		Context("[Serial]with sidecar feature gate disabled", func() {
 			BeforeEach(func() {
 				tests.DisableFeatureGate(virtconfig.SidecarGate)
@@ -177,18 +206,15 @@ func getHookSidecarLogs(virtCli kubecli.KubevirtClient, vmi *v1.VirtualMachineIn
 func getVmDomainXml(virtCli kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance) string {
 	podName := tests.GetVmPodName(virtCli, vmi)
 
-	arg := ""
-	if putil.IsNonRootVMI(vmi) {
-		arg = "-c " + "qemu+unix:///session?socket=/var/run/libvirt/libvirt-sock"
-	}
+	execArgs := []string{"exec", "-ti", "--namespace", vmi.GetObjectMeta().GetNamespace(), podName, "--container", "compute", "--"}
 
 	// passing an empty namespace allows to position --namespace argument correctly
-	vmNameListRaw, _, err := tests.RunCommandWithNS("", "kubectl", "exec", "-ti", "--namespace", vmi.GetObjectMeta().GetNamespace(), podName, "--container", "compute", "--", "virsh", arg, "list", "--name")
+	vmNameListRaw, _, err := tests.RunCommandWithNS("", "kubectl", append(execArgs, tests.VirshCommand(vmi, "list", "--name")...)...)
 	Expect(err).ToNot(HaveOccurred())
 
 	vmName := strings.Split(vmNameListRaw, "\n")[0]
 	// passing an empty namespace allows to position --namespace argument correctly
-	vmDomainXML, _, err := tests.RunCommandWithNS("", "kubectl", "exec", "-ti", "--namespace", vmi.GetObjectMeta().GetNamespace(), podName, "--container", "compute", "--", "virsh", arg, "dumpxml", vmName)
+	vmDomainXML, _, err := tests.RunCommandWithNS("", "kubectl", append(execArgs, tests.VirshCommand(vmi, "dumpxml", vmName)...)...)
 	Expect(err).ToNot(HaveOccurred())
 
 	return vmDomainXML
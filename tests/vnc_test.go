@@ -125,6 +125,10 @@ var _ = Describe("[Serial][rfe_id:127][crit:medium][vendor:cnv-qe@redhat.com][le
 					vncConnect()
 				}
 			})
+
+			It("should receive the RFB handshake", func() {
+				Expect(tests.ExpectVNCConnectable(vmi)).To(Succeed())
+			})
 		})
 
 		table.DescribeTable("[rfe_id:127][crit:medium][vendor:cnv-qe@redhat.com][level:component]should upgrade websocket connection which look like coming from a browser", func(subresource string) {